@@ -0,0 +1,536 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const fastioPath = "github.com/PavelKhromykhGo/fastio/fastio"
+
+// run разбирает пакет в dir и записывает код для types в файл output.
+func run(dir string, types []string, output string) error {
+	pkg, err := parsePackage(dir, output)
+	if err != nil {
+		return err
+	}
+	src, err := generate(pkg, types, "fastiogen -type="+strings.Join(types, ","))
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, output), src, 0o644)
+}
+
+// packageInfo — объявления типов пакета, для которого генерируется код.
+type packageInfo struct {
+	name  string
+	specs map[string]*ast.TypeSpec
+}
+
+// parsePackage читает все .go файлы dir, кроме тестов и ранее
+// сгенерированного файла skip.
+func parsePackage(dir, skip string) (*packageInfo, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	pkg := &packageInfo{specs: make(map[string]*ast.TypeSpec)}
+	fset := token.NewFileSet()
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") || filepath.Base(path) == skip {
+			continue
+		}
+		f, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		if pkg.name == "" {
+			pkg.name = f.Name.Name
+		} else if pkg.name != f.Name.Name {
+			return nil, fmt.Errorf("%s: multiple packages: %s and %s", dir, pkg.name, f.Name.Name)
+		}
+		for _, decl := range f.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				ts := spec.(*ast.TypeSpec)
+				pkg.specs[ts.Name.Name] = ts
+			}
+		}
+	}
+	if pkg.name == "" {
+		return nil, fmt.Errorf("%s: no Go files", dir)
+	}
+	return pkg, nil
+}
+
+// typeKind — способ чтения и записи значения.
+type typeKind int
+
+const (
+	kindBasic  typeKind = iota // одно число, строка или bool
+	kindBytes                  // []byte, один токен
+	kindSlice                  // срез со счётчиком или len=Field
+	kindArray                  // массив фиксированной длины
+	kindStruct                 // структура пакета, readX/writeX
+)
+
+// typeInfo описывает тип поля. name — имя типа так, как оно записано
+// в коде (для преобразований и make), basic — базовый тип для kindBasic.
+type typeInfo struct {
+	kind  typeKind
+	name  string
+	basic string
+	elem  *typeInfo
+}
+
+var basicTypes = map[string]string{
+	"int": "int", "int8": "int8", "int16": "int16", "int32": "int32", "int64": "int64",
+	"uint": "uint", "uint8": "uint8", "uint16": "uint16", "uint32": "uint32", "uint64": "uint64",
+	"uintptr": "uintptr", "byte": "uint8", "rune": "int32",
+	"float32": "float32", "float64": "float64", "string": "string", "bool": "bool",
+}
+
+// directReaders — методы FastReader, возвращающие ровно базовый тип.
+var directReaders = map[string]string{
+	"int":     "NextInt",
+	"int64":   "NextInt64",
+	"uint64":  "NextUint64",
+	"float32": "NextFloat32",
+	"float64": "NextFloat64",
+	"string":  "NextWord",
+	"bool":    "NextBool",
+}
+
+// field — поле структуры в порядке чтения.
+type field struct {
+	name   string
+	typ    *typeInfo
+	order  int
+	lenRef string
+}
+
+type generator struct {
+	pkg   *packageInfo
+	buf   bytes.Buffer
+	queue []string
+	seen  map[string]bool
+	// helper — префикс вспомогательных функций файла. Он включает имя
+	// первого типа, чтобы файлы нескольких запусков fastiogen в одном
+	// пакете не объявляли одинаковые функции.
+	helper string
+}
+
+// generate возвращает отформатированный исходный код для types.
+func generate(pkg *packageInfo, types []string, cmd string) ([]byte, error) {
+	g := &generator{pkg: pkg, seen: make(map[string]bool), helper: "fastio" + types[0]}
+	fmt.Fprintf(&g.buf, "// Code generated by %s; DO NOT EDIT.\n\n", cmd)
+	fmt.Fprintf(&g.buf, "package %s\n\nimport (\n\t\"io\"\n\t\"slices\"\n\n\t%q\n)\n", pkg.name, fastioPath)
+
+	for _, name := range types {
+		if _, err := g.structFields(name); err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&g.buf, `
+// Read%[1]s читает запись %[1]s из fr: поля по порядку, каждое — одним
+// токеном, как FastReader.Decode.
+func Read%[1]s(fr *fastio.FastReader) (%[1]s, error) {
+	var v %[1]s
+	err := read%[1]s(fr, &v, true)
+	return v, err
+}
+
+// Write%[1]s записывает v одной строкой: поля через пробел и '\n' в конце.
+func Write%[1]s(fw *fastio.FastWriter, v %[1]s) error {
+	if err := write%[1]s(fw, &v, false); err != nil {
+		return err
+	}
+	return fw.WriteByte('\n')
+}
+`, name)
+		g.enqueue(name)
+	}
+	for len(g.queue) > 0 {
+		name := g.queue[0]
+		g.queue = g.queue[1:]
+		if err := g.genStruct(name); err != nil {
+			return nil, err
+		}
+	}
+	fmt.Fprintf(&g.buf, `
+// %[1]sEOF превращает io.EOF посреди записи в io.ErrUnexpectedEOF.
+func %[1]sEOF(err error, first bool) error {
+	if err == io.EOF && !first {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// %[1]sGrow возвращает s длины i+1 для чтения i-го из n элементов.
+// n приходит из входа, поэтому память выделяется порциями по мере
+// чтения элементов, а не сразу под n.
+func %[1]sGrow[S ~[]E, E any](s S, i, n int) S {
+	if i == cap(s) {
+		s = slices.Grow(s[:i], min(n-i, max(i, 1024)))
+	}
+	return s[:i+1]
+}
+`, g.helper)
+	src, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w", err)
+	}
+	return src, nil
+}
+
+func (g *generator) enqueue(name string) {
+	if !g.seen[name] {
+		g.seen[name] = true
+		g.queue = append(g.queue, name)
+	}
+}
+
+// structFields возвращает поля структуры name в порядке чтения,
+// применяя теги `fastio:"..."` так же, как FastReader.Decode.
+func (g *generator) structFields(name string) ([]field, error) {
+	ts, ok := g.pkg.specs[name]
+	if !ok {
+		return nil, fmt.Errorf("type %s not found", name)
+	}
+	st, ok := ts.Type.(*ast.StructType)
+	if !ok {
+		return nil, fmt.Errorf("type %s is not a struct", name)
+	}
+	var fields []field
+	pos := 0
+	for _, f := range st.Fields.List {
+		names := make([]string, 0, len(f.Names))
+		for _, id := range f.Names {
+			names = append(names, id.Name)
+		}
+		if len(names) == 0 {
+			// Встроенное поле называется по имени типа.
+			id, ok := f.Type.(*ast.Ident)
+			if !ok {
+				return nil, fmt.Errorf("%s: unsupported embedded field %s", name, exprString(f.Type))
+			}
+			names = append(names, id.Name)
+		}
+		var tag string
+		if f.Tag != nil {
+			s, err := strconv.Unquote(f.Tag.Value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: bad tag %s", name, f.Tag.Value)
+			}
+			tag = reflect.StructTag(s).Get("fastio")
+		}
+		for _, fname := range names {
+			i := pos
+			pos++
+			if !ast.IsExported(fname) || tag == "-" {
+				continue
+			}
+			fd := field{name: fname, order: i}
+			if tag != "" {
+				for _, opt := range strings.Split(tag, ",") {
+					key, val, _ := strings.Cut(opt, "=")
+					switch key {
+					case "order":
+						n, err := strconv.Atoi(val)
+						if err != nil || n < 0 {
+							return nil, fmt.Errorf("%s.%s: invalid fastio tag %q", name, fname, opt)
+						}
+						fd.order = n
+					case "len":
+						if val == "" {
+							return nil, fmt.Errorf("%s.%s: invalid fastio tag %q", name, fname, opt)
+						}
+						fd.lenRef = val
+					default:
+						return nil, fmt.Errorf("%s.%s: invalid fastio tag %q", name, fname, opt)
+					}
+				}
+			}
+			t, err := g.resolve(f.Type)
+			if err != nil {
+				return nil, fmt.Errorf("%s.%s: %w", name, fname, err)
+			}
+			fd.typ = t
+			fields = append(fields, fd)
+		}
+	}
+	sort.SliceStable(fields, func(i, j int) bool { return fields[i].order < fields[j].order })
+
+	decoded := make(map[string]*typeInfo, len(fields))
+	for _, f := range fields {
+		if f.lenRef != "" {
+			lt, ok := decoded[f.lenRef]
+			if f.typ.kind != kindSlice || !ok || lt.kind != kindBasic || !isInteger(lt.basic) {
+				return nil, fmt.Errorf("%s.%s: invalid fastio tag %q", name, f.name, "len="+f.lenRef)
+			}
+		}
+		decoded[f.name] = f.typ
+	}
+	return fields, nil
+}
+
+// resolve определяет typeInfo по выражению типа, раскрывая именованные
+// типы пакета до базовых.
+func (g *generator) resolve(expr ast.Expr) (*typeInfo, error) {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if b, ok := basicTypes[e.Name]; ok {
+			return &typeInfo{kind: kindBasic, name: e.Name, basic: b}, nil
+		}
+		ts, ok := g.pkg.specs[e.Name]
+		if !ok {
+			return nil, fmt.Errorf("unknown type %s", e.Name)
+		}
+		if _, ok := ts.Type.(*ast.StructType); ok {
+			return &typeInfo{kind: kindStruct, name: e.Name}, nil
+		}
+		u, err := g.resolve(ts.Type)
+		if err != nil {
+			return nil, err
+		}
+		t := *u
+		t.name = e.Name
+		return &t, nil
+	case *ast.ArrayType:
+		elem, err := g.resolve(e.Elt)
+		if err != nil {
+			return nil, err
+		}
+		t := &typeInfo{kind: kindSlice, name: exprString(e), elem: elem}
+		if e.Len != nil {
+			t.kind = kindArray
+		} else if elem.kind == kindBasic && (elem.name == "byte" || elem.name == "uint8") {
+			t.kind = kindBytes
+		}
+		return t, nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", exprString(expr))
+}
+
+func (g *generator) genStruct(name string) error {
+	fields, err := g.structFields(name)
+	if err != nil {
+		return err
+	}
+
+	lenTypes := make(map[string]*typeInfo, len(fields))
+	for _, f := range fields {
+		lenTypes[f.name] = f.typ
+	}
+
+	// lenExpr возвращает длину среза с тегом len=Field как int.
+	lenExpr := func(f field) string {
+		n := "v." + f.lenRef
+		if lt := lenTypes[f.lenRef]; lt.name != "int" {
+			n = "int(" + n + ")"
+		}
+		return n
+	}
+
+	var body strings.Builder
+	usesErr := false
+	first := "first"
+	for _, f := range fields {
+		x := "v." + f.name
+		if f.lenRef != "" {
+			g.readSlice(&body, x, f.typ, lenExpr(f), "", 0)
+		} else {
+			usesErr = g.read(&body, x, f.typ, first, 0) || usesErr
+		}
+		first = "false"
+	}
+	fmt.Fprintf(&g.buf, "\nfunc read%[1]s(fr *fastio.FastReader, v *%[1]s, first bool) error {\n", name)
+	if usesErr {
+		g.buf.WriteString("var err error\n")
+	}
+	fmt.Fprintf(&g.buf, "%sreturn nil\n}\n", body.String())
+
+	body.Reset()
+	// Срез с len=Field, длина которого не равна Field, прочитался бы
+	// неверно; проверяем до записи первого поля.
+	for _, f := range fields {
+		if f.lenRef != "" {
+			fmt.Fprintf(&body, "if len(v.%s) != %s {\nreturn fastio.ErrLenMismatch\n}\n", f.name, lenExpr(f))
+		}
+	}
+	sep := "sep"
+	for _, f := range fields {
+		x := "v." + f.name
+		if f.lenRef != "" {
+			g.writeElems(&body, x, f.typ.elem, 0)
+		} else {
+			g.write(&body, x, f.typ, sep, 0)
+		}
+		sep = "true"
+	}
+	fmt.Fprintf(&g.buf, "\nfunc write%[1]s(fw *fastio.FastWriter, v *%[1]s, sep bool) error {\n", name)
+	fmt.Fprintf(&g.buf, "%sreturn nil\n}\n", body.String())
+	return nil
+}
+
+// read генерирует чтение значения типа t в x. first — выражение,
+// истинное, если это первый токен записи. Возвращает true, если код
+// использует объявленную в функции переменную err.
+func (g *generator) read(b *strings.Builder, x string, t *typeInfo, first string, depth int) bool {
+	switch t.kind {
+	case kindBasic:
+		if m, ok := directReaders[t.basic]; ok && t.name == t.basic {
+			fmt.Fprintf(b, "if %s, err = fr.%s(); err != nil {\nreturn %sEOF(err, %s)\n}\n", x, m, g.helper, first)
+			return true
+		}
+		if t.basic != "string" && t.basic != "bool" {
+			fmt.Fprintf(b, "if %s, err = fastio.Next[%s](fr); err != nil {\nreturn %sEOF(err, %s)\n}\n", x, t.name, g.helper, first)
+			return true
+		}
+		fmt.Fprintf(b, "{\ns, err := fr.%s()\nif err != nil {\nreturn %sEOF(err, %s)\n}\n%s = %s(s)\n}\n",
+			directReaders[t.basic], g.helper, first, x, t.name)
+	case kindBytes:
+		fmt.Fprintf(b, "{\nb, err := fr.NextWordBytes()\nif err != nil {\nreturn %sEOF(err, %s)\n}\n%s = append(%s[:0], b...)\n}\n",
+			g.helper, first, x, x)
+	case kindStruct:
+		g.enqueue(t.name)
+		fmt.Fprintf(b, "if err := read%s(fr, &%s, %s); err != nil {\nreturn err\n}\n", t.name, x, first)
+	case kindArray:
+		i := index(depth)
+		elemFirst := "false"
+		if first != "false" {
+			elemFirst = fmt.Sprintf("%s && %s == 0", first, i)
+		}
+		fmt.Fprintf(b, "for %s := range %s {\n", i, x)
+		uses := g.read(b, fmt.Sprintf("%s[%s]", x, i), t.elem, elemFirst, depth+1)
+		b.WriteString("}\n")
+		return uses
+	case kindSlice:
+		g.readSlice(b, x, t, "", first, depth)
+	}
+	return false
+}
+
+// readSlice генерирует чтение среза: длина берётся из выражения n
+// или, если оно пустое, читается из входа как отдельный токен.
+// Срез получает свой блок со своей переменной err.
+func (g *generator) readSlice(b *strings.Builder, x string, t *typeInfo, n, first string, depth int) {
+	i := index(depth)
+	var loop strings.Builder
+	uses := g.read(&loop, fmt.Sprintf("%s[%s]", x, i), t.elem, "false", depth+1)
+
+	b.WriteString("{\n")
+	if n == "" {
+		fmt.Fprintf(b, "n, err := fr.NextInt()\nif err != nil {\nreturn %sEOF(err, %s)\n}\n", g.helper, first)
+	} else {
+		fmt.Fprintf(b, "n := %s\n", n)
+		if uses {
+			b.WriteString("var err error\n")
+		}
+	}
+	fmt.Fprintf(b, "if n < 0 {\nreturn fastio.ErrRange\n}\n")
+	fmt.Fprintf(b, "%[1]s = %[1]s[:0]\nfor %[2]s := 0; %[2]s < n; %[2]s++ {\n%[1]s = %[4]sGrow(%[1]s, %[2]s, n)\n%[3]s}\n}\n", x, i, loop.String(), g.helper)
+}
+
+// write генерирует запись значения x типа t. sep — выражение, истинное,
+// если перед первым токеном нужен пробел.
+func (g *generator) write(b *strings.Builder, x string, t *typeInfo, sep string, depth int) {
+	switch t.kind {
+	case kindBasic:
+		writeSep(b, sep)
+		fmt.Fprintf(b, "if err := fw.%s; err != nil {\nreturn err\n}\n", writeCall(x, t))
+	case kindBytes:
+		writeSep(b, sep)
+		fmt.Fprintf(b, "if err := fw.WriteTokenBytes(%s); err != nil {\nreturn err\n}\n", x)
+	case kindStruct:
+		g.enqueue(t.name)
+		fmt.Fprintf(b, "if err := write%s(fw, &%s, %s); err != nil {\nreturn err\n}\n", t.name, x, sep)
+	case kindArray:
+		i := index(depth)
+		elemSep := "true"
+		switch sep {
+		case "false":
+			elemSep = i + " > 0"
+		case "true":
+		default:
+			elemSep = fmt.Sprintf("%s || %s > 0", sep, i)
+		}
+		fmt.Fprintf(b, "for %s := range %s {\n", i, x)
+		g.write(b, fmt.Sprintf("%s[%s]", x, i), t.elem, elemSep, depth+1)
+		b.WriteString("}\n")
+	case kindSlice:
+		writeSep(b, sep)
+		fmt.Fprintf(b, "if err := fw.WriteInt(len(%s)); err != nil {\nreturn err\n}\n", x)
+		g.writeElems(b, x, t.elem, depth)
+	}
+}
+
+// writeElems генерирует запись элементов среза x, каждый после пробела.
+func (g *generator) writeElems(b *strings.Builder, x string, elem *typeInfo, depth int) {
+	i := index(depth)
+	fmt.Fprintf(b, "for %s := range %s {\n", i, x)
+	g.write(b, fmt.Sprintf("%s[%s]", x, i), elem, "true", depth+1)
+	b.WriteString("}\n")
+}
+
+func writeSep(b *strings.Builder, sep string) {
+	switch sep {
+	case "false":
+	case "true":
+		b.WriteString("if err := fw.WriteByte(' '); err != nil {\nreturn err\n}\n")
+	default:
+		fmt.Fprintf(b, "if %s {\nif err := fw.WriteByte(' '); err != nil {\nreturn err\n}\n}\n", sep)
+	}
+}
+
+// writeCall возвращает вызов метода FastWriter для базового типа.
+func writeCall(x string, t *typeInfo) string {
+	conv := func(to string) string {
+		if t.name == to {
+			return x
+		}
+		return to + "(" + x + ")"
+	}
+	switch {
+	case t.basic == "int":
+		return "WriteInt(" + conv("int") + ")"
+	case t.basic == "float32" || t.basic == "float64":
+		return "WriteFloat64(" + conv("float64") + ", -1)"
+	case t.basic == "string":
+		return "WriteToken(" + conv("string") + ")"
+	case t.basic == "bool":
+		return "WriteBool(" + conv("bool") + ")"
+	case strings.HasPrefix(t.basic, "int"):
+		return "WriteInt64(" + conv("int64") + ")"
+	default:
+		return "WriteUint64(" + conv("uint64") + ")"
+	}
+}
+
+func isInteger(basic string) bool {
+	return strings.HasPrefix(basic, "int") || strings.HasPrefix(basic, "uint")
+}
+
+// index возвращает имя переменной цикла для уровня вложенности depth.
+func index(depth int) string {
+	return fmt.Sprintf("i%d", depth)
+}
+
+func exprString(e ast.Expr) string {
+	var b bytes.Buffer
+	if err := format.Node(&b, token.NewFileSet(), e); err != nil {
+		return fmt.Sprintf("%T", e)
+	}
+	return b.String()
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestGeneratedUpToDate проверяет, что закоммиченные файлы пакета record
+// совпадают с тем, что генерирует текущая версия fastiogen. Пакет
+// содержит два файла от разных запусков, поэтому его сборка заодно
+// проверяет, что их вспомогательные функции не конфликтуют.
+func TestGeneratedUpToDate(t *testing.T) {
+	dir := filepath.Join("internal", "record")
+	tests := []struct {
+		output string
+		types  []string
+	}{
+		{"record_fastio.go", []string{"Order", "Point"}},
+		{"batch_fastio.go", []string{"Batch"}},
+	}
+	for _, tt := range tests {
+		pkg, err := parsePackage(dir, tt.output)
+		if err != nil {
+			t.Fatalf("parsePackage error: %v", err)
+		}
+		got, err := generate(pkg, tt.types, "fastiogen -type="+strings.Join(tt.types, ","))
+		if err != nil {
+			t.Fatalf("generate error: %v", err)
+		}
+		want, err := os.ReadFile(filepath.Join(dir, tt.output))
+		if err != nil {
+			t.Fatalf("ReadFile error: %v", err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatalf("%s is stale; run go generate ./cmd/fastiogen/internal/record", tt.output)
+		}
+	}
+}
+
+// TestGenerateTwoFiles проверяет, что два запуска fastiogen в одном
+// пакете не объявляют одинаковые функции верхнего уровня.
+func TestGenerateTwoFiles(t *testing.T) {
+	pkg := parseTestPackage(t, "type A struct{ X []int }\ntype B struct{ Y []string }")
+	decls := make(map[string]string)
+	for _, typ := range []string{"A", "B"} {
+		src, err := generate(pkg, []string{typ}, "fastiogen")
+		if err != nil {
+			t.Fatalf("generate(%s) error: %v", typ, err)
+		}
+		f, err := parser.ParseFile(token.NewFileSet(), typ+".go", src, 0)
+		if err != nil {
+			t.Fatalf("ParseFile(%s) error: %v", typ, err)
+		}
+		for _, d := range f.Decls {
+			if fd, ok := d.(*ast.FuncDecl); ok {
+				if prev, ok := decls[fd.Name.Name]; ok {
+					t.Errorf("%s is declared for both %s and %s", fd.Name.Name, prev, typ)
+				}
+				decls[fd.Name.Name] = typ
+			}
+		}
+	}
+}
+
+func parseTestPackage(t *testing.T, src string) *packageInfo {
+	t.Helper()
+	f, err := parser.ParseFile(token.NewFileSet(), "p.go", "package p\n"+src, 0)
+	if err != nil {
+		t.Fatalf("ParseFile error: %v", err)
+	}
+	pkg := &packageInfo{name: "p", specs: make(map[string]*ast.TypeSpec)}
+	for _, decl := range f.Decls {
+		for _, spec := range decl.(*ast.GenDecl).Specs {
+			ts := spec.(*ast.TypeSpec)
+			pkg.specs[ts.Name.Name] = ts
+		}
+	}
+	return pkg
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		src, typ, want string
+	}{
+		{"type T struct{ A int }", "U", "type U not found"},
+		{"type T int", "T", "not a struct"},
+		{"type T struct{ M map[string]int }", "T", "unsupported type map[string]int"},
+		{"type T struct{ A time.Time }", "T", "unsupported type time.Time"},
+		{"type T struct{ A int `fastio:\"size=1\"` }", "T", `invalid fastio tag "size=1"`},
+		{"type T struct{ S []int `fastio:\"len=N\"`; N int }", "T", `invalid fastio tag "len=N"`},
+		{"type T struct{ N string; S []int `fastio:\"len=N\"` }", "T", `invalid fastio tag "len=N"`},
+	}
+	for _, tt := range tests {
+		_, err := generate(parseTestPackage(t, tt.src), []string{tt.typ}, "fastiogen")
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("generate(%q) error = %v; want %q", tt.src, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+// Code generated by fastiogen -type=Batch; DO NOT EDIT.
+
+package record
+
+import (
+	"io"
+	"slices"
+
+	"github.com/PavelKhromykhGo/fastio/fastio"
+)
+
+// ReadBatch читает запись Batch из fr: поля по порядку, каждое — одним
+// токеном, как FastReader.Decode.
+func ReadBatch(fr *fastio.FastReader) (Batch, error) {
+	var v Batch
+	err := readBatch(fr, &v, true)
+	return v, err
+}
+
+// WriteBatch записывает v одной строкой: поля через пробел и '\n' в конце.
+func WriteBatch(fw *fastio.FastWriter, v Batch) error {
+	if err := writeBatch(fw, &v, false); err != nil {
+		return err
+	}
+	return fw.WriteByte('\n')
+}
+
+func readBatch(fr *fastio.FastReader, v *Batch, first bool) error {
+	var err error
+	if v.Name, err = fr.NextWord(); err != nil {
+		return fastioBatchEOF(err, first)
+	}
+	if v.N, err = fr.NextInt(); err != nil {
+		return fastioBatchEOF(err, false)
+	}
+	{
+		n := v.N
+		var err error
+		if n < 0 {
+			return fastio.ErrRange
+		}
+		v.Values = v.Values[:0]
+		for i0 := 0; i0 < n; i0++ {
+			v.Values = fastioBatchGrow(v.Values, i0, n)
+			if v.Values[i0], err = fr.NextInt64(); err != nil {
+				return fastioBatchEOF(err, false)
+			}
+		}
+	}
+	{
+		n, err := fr.NextInt()
+		if err != nil {
+			return fastioBatchEOF(err, false)
+		}
+		if n < 0 {
+			return fastio.ErrRange
+		}
+		v.Tags = v.Tags[:0]
+		for i0 := 0; i0 < n; i0++ {
+			v.Tags = fastioBatchGrow(v.Tags, i0, n)
+			if v.Tags[i0], err = fr.NextWord(); err != nil {
+				return fastioBatchEOF(err, false)
+			}
+		}
+	}
+	return nil
+}
+
+func writeBatch(fw *fastio.FastWriter, v *Batch, sep bool) error {
+	if len(v.Values) != v.N {
+		return fastio.ErrLenMismatch
+	}
+	if sep {
+		if err := fw.WriteByte(' '); err != nil {
+			return err
+		}
+	}
+	if err := fw.WriteToken(v.Name); err != nil {
+		return err
+	}
+	if err := fw.WriteByte(' '); err != nil {
+		return err
+	}
+	if err := fw.WriteInt(v.N); err != nil {
+		return err
+	}
+	for i0 := range v.Values {
+		if err := fw.WriteByte(' '); err != nil {
+			return err
+		}
+		if err := fw.WriteInt64(v.Values[i0]); err != nil {
+			return err
+		}
+	}
+	if err := fw.WriteByte(' '); err != nil {
+		return err
+	}
+	if err := fw.WriteInt(len(v.Tags)); err != nil {
+		return err
+	}
+	for i0 := range v.Tags {
+		if err := fw.WriteByte(' '); err != nil {
+			return err
+		}
+		if err := fw.WriteToken(v.Tags[i0]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fastioBatchEOF превращает io.EOF посреди записи в io.ErrUnexpectedEOF.
+func fastioBatchEOF(err error, first bool) error {
+	if err == io.EOF && !first {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// fastioBatchGrow возвращает s длины i+1 для чтения i-го из n элементов.
+// n приходит из входа, поэтому память выделяется порциями по мере
+// чтения элементов, а не сразу под n.
+func fastioBatchGrow[S ~[]E, E any](s S, i, n int) S {
+	if i == cap(s) {
+		s = slices.Grow(s[:i], min(n-i, max(i, 1024)))
+	}
+	return s[:i+1]
+}
@@ -0,0 +1,51 @@
+// Package record содержит типы для проверки кода, который генерирует
+// fastiogen: record_fastio.go создаётся командой go generate.
+package record
+
+//go:generate go run ../.. -type=Order,Point -output=record_fastio.go
+//go:generate go run ../.. -type=Batch -output=batch_fastio.go
+
+// ID — именованный целый тип.
+type ID uint32
+
+// Label — именованный строковый тип.
+type Label string
+
+// Point — вложенная структура.
+type Point struct {
+	X, Y int16
+}
+
+// Order — запись со всеми поддерживаемыми видами полей.
+type Order struct {
+	ID     ID
+	Name   string
+	Label  Label
+	Score  float64
+	Ratio  float32
+	Active bool
+	Count  int
+	Big    int64
+	Mask   uint64
+	Small  int8
+	Flags  uint16
+	Pos    Point
+	Tags   [2]string
+	N      int
+	Items  []int32 `fastio:"len=N"`
+	Path   []Point
+	Raw    []byte
+	Grid   [][]uint8
+	Cache  string `fastio:"-"`
+	First  int    `fastio:"order=0"`
+	hidden int
+}
+
+// Batch генерируется отдельным запуском fastiogen в batch_fastio.go,
+// чтобы проверить, что два сгенерированных файла уживаются в одном пакете.
+type Batch struct {
+	Name   string
+	N      int
+	Values []int64 `fastio:"len=N"`
+	Tags   []string
+}
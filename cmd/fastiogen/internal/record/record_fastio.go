@@ -0,0 +1,339 @@
+// Code generated by fastiogen -type=Order,Point; DO NOT EDIT.
+
+package record
+
+import (
+	"io"
+	"slices"
+
+	"github.com/PavelKhromykhGo/fastio/fastio"
+)
+
+// ReadOrder читает запись Order из fr: поля по порядку, каждое — одним
+// токеном, как FastReader.Decode.
+func ReadOrder(fr *fastio.FastReader) (Order, error) {
+	var v Order
+	err := readOrder(fr, &v, true)
+	return v, err
+}
+
+// WriteOrder записывает v одной строкой: поля через пробел и '\n' в конце.
+func WriteOrder(fw *fastio.FastWriter, v Order) error {
+	if err := writeOrder(fw, &v, false); err != nil {
+		return err
+	}
+	return fw.WriteByte('\n')
+}
+
+// ReadPoint читает запись Point из fr: поля по порядку, каждое — одним
+// токеном, как FastReader.Decode.
+func ReadPoint(fr *fastio.FastReader) (Point, error) {
+	var v Point
+	err := readPoint(fr, &v, true)
+	return v, err
+}
+
+// WritePoint записывает v одной строкой: поля через пробел и '\n' в конце.
+func WritePoint(fw *fastio.FastWriter, v Point) error {
+	if err := writePoint(fw, &v, false); err != nil {
+		return err
+	}
+	return fw.WriteByte('\n')
+}
+
+func readOrder(fr *fastio.FastReader, v *Order, first bool) error {
+	var err error
+	if v.ID, err = fastio.Next[ID](fr); err != nil {
+		return fastioOrderEOF(err, first)
+	}
+	if v.First, err = fr.NextInt(); err != nil {
+		return fastioOrderEOF(err, false)
+	}
+	if v.Name, err = fr.NextWord(); err != nil {
+		return fastioOrderEOF(err, false)
+	}
+	{
+		s, err := fr.NextWord()
+		if err != nil {
+			return fastioOrderEOF(err, false)
+		}
+		v.Label = Label(s)
+	}
+	if v.Score, err = fr.NextFloat64(); err != nil {
+		return fastioOrderEOF(err, false)
+	}
+	if v.Ratio, err = fr.NextFloat32(); err != nil {
+		return fastioOrderEOF(err, false)
+	}
+	if v.Active, err = fr.NextBool(); err != nil {
+		return fastioOrderEOF(err, false)
+	}
+	if v.Count, err = fr.NextInt(); err != nil {
+		return fastioOrderEOF(err, false)
+	}
+	if v.Big, err = fr.NextInt64(); err != nil {
+		return fastioOrderEOF(err, false)
+	}
+	if v.Mask, err = fr.NextUint64(); err != nil {
+		return fastioOrderEOF(err, false)
+	}
+	if v.Small, err = fastio.Next[int8](fr); err != nil {
+		return fastioOrderEOF(err, false)
+	}
+	if v.Flags, err = fastio.Next[uint16](fr); err != nil {
+		return fastioOrderEOF(err, false)
+	}
+	if err := readPoint(fr, &v.Pos, false); err != nil {
+		return err
+	}
+	for i0 := range v.Tags {
+		if v.Tags[i0], err = fr.NextWord(); err != nil {
+			return fastioOrderEOF(err, false)
+		}
+	}
+	if v.N, err = fr.NextInt(); err != nil {
+		return fastioOrderEOF(err, false)
+	}
+	{
+		n := v.N
+		var err error
+		if n < 0 {
+			return fastio.ErrRange
+		}
+		v.Items = v.Items[:0]
+		for i0 := 0; i0 < n; i0++ {
+			v.Items = fastioOrderGrow(v.Items, i0, n)
+			if v.Items[i0], err = fastio.Next[int32](fr); err != nil {
+				return fastioOrderEOF(err, false)
+			}
+		}
+	}
+	{
+		n, err := fr.NextInt()
+		if err != nil {
+			return fastioOrderEOF(err, false)
+		}
+		if n < 0 {
+			return fastio.ErrRange
+		}
+		v.Path = v.Path[:0]
+		for i0 := 0; i0 < n; i0++ {
+			v.Path = fastioOrderGrow(v.Path, i0, n)
+			if err := readPoint(fr, &v.Path[i0], false); err != nil {
+				return err
+			}
+		}
+	}
+	{
+		b, err := fr.NextWordBytes()
+		if err != nil {
+			return fastioOrderEOF(err, false)
+		}
+		v.Raw = append(v.Raw[:0], b...)
+	}
+	{
+		n, err := fr.NextInt()
+		if err != nil {
+			return fastioOrderEOF(err, false)
+		}
+		if n < 0 {
+			return fastio.ErrRange
+		}
+		v.Grid = v.Grid[:0]
+		for i0 := 0; i0 < n; i0++ {
+			v.Grid = fastioOrderGrow(v.Grid, i0, n)
+			{
+				b, err := fr.NextWordBytes()
+				if err != nil {
+					return fastioOrderEOF(err, false)
+				}
+				v.Grid[i0] = append(v.Grid[i0][:0], b...)
+			}
+		}
+	}
+	return nil
+}
+
+func writeOrder(fw *fastio.FastWriter, v *Order, sep bool) error {
+	if len(v.Items) != v.N {
+		return fastio.ErrLenMismatch
+	}
+	if sep {
+		if err := fw.WriteByte(' '); err != nil {
+			return err
+		}
+	}
+	if err := fw.WriteUint64(uint64(v.ID)); err != nil {
+		return err
+	}
+	if err := fw.WriteByte(' '); err != nil {
+		return err
+	}
+	if err := fw.WriteInt(v.First); err != nil {
+		return err
+	}
+	if err := fw.WriteByte(' '); err != nil {
+		return err
+	}
+	if err := fw.WriteToken(v.Name); err != nil {
+		return err
+	}
+	if err := fw.WriteByte(' '); err != nil {
+		return err
+	}
+	if err := fw.WriteToken(string(v.Label)); err != nil {
+		return err
+	}
+	if err := fw.WriteByte(' '); err != nil {
+		return err
+	}
+	if err := fw.WriteFloat64(v.Score, -1); err != nil {
+		return err
+	}
+	if err := fw.WriteByte(' '); err != nil {
+		return err
+	}
+	if err := fw.WriteFloat64(float64(v.Ratio), -1); err != nil {
+		return err
+	}
+	if err := fw.WriteByte(' '); err != nil {
+		return err
+	}
+	if err := fw.WriteBool(v.Active); err != nil {
+		return err
+	}
+	if err := fw.WriteByte(' '); err != nil {
+		return err
+	}
+	if err := fw.WriteInt(v.Count); err != nil {
+		return err
+	}
+	if err := fw.WriteByte(' '); err != nil {
+		return err
+	}
+	if err := fw.WriteInt64(v.Big); err != nil {
+		return err
+	}
+	if err := fw.WriteByte(' '); err != nil {
+		return err
+	}
+	if err := fw.WriteUint64(v.Mask); err != nil {
+		return err
+	}
+	if err := fw.WriteByte(' '); err != nil {
+		return err
+	}
+	if err := fw.WriteInt64(int64(v.Small)); err != nil {
+		return err
+	}
+	if err := fw.WriteByte(' '); err != nil {
+		return err
+	}
+	if err := fw.WriteUint64(uint64(v.Flags)); err != nil {
+		return err
+	}
+	if err := writePoint(fw, &v.Pos, true); err != nil {
+		return err
+	}
+	for i0 := range v.Tags {
+		if err := fw.WriteByte(' '); err != nil {
+			return err
+		}
+		if err := fw.WriteToken(v.Tags[i0]); err != nil {
+			return err
+		}
+	}
+	if err := fw.WriteByte(' '); err != nil {
+		return err
+	}
+	if err := fw.WriteInt(v.N); err != nil {
+		return err
+	}
+	for i0 := range v.Items {
+		if err := fw.WriteByte(' '); err != nil {
+			return err
+		}
+		if err := fw.WriteInt64(int64(v.Items[i0])); err != nil {
+			return err
+		}
+	}
+	if err := fw.WriteByte(' '); err != nil {
+		return err
+	}
+	if err := fw.WriteInt(len(v.Path)); err != nil {
+		return err
+	}
+	for i0 := range v.Path {
+		if err := writePoint(fw, &v.Path[i0], true); err != nil {
+			return err
+		}
+	}
+	if err := fw.WriteByte(' '); err != nil {
+		return err
+	}
+	if err := fw.WriteTokenBytes(v.Raw); err != nil {
+		return err
+	}
+	if err := fw.WriteByte(' '); err != nil {
+		return err
+	}
+	if err := fw.WriteInt(len(v.Grid)); err != nil {
+		return err
+	}
+	for i0 := range v.Grid {
+		if err := fw.WriteByte(' '); err != nil {
+			return err
+		}
+		if err := fw.WriteTokenBytes(v.Grid[i0]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readPoint(fr *fastio.FastReader, v *Point, first bool) error {
+	var err error
+	if v.X, err = fastio.Next[int16](fr); err != nil {
+		return fastioOrderEOF(err, first)
+	}
+	if v.Y, err = fastio.Next[int16](fr); err != nil {
+		return fastioOrderEOF(err, false)
+	}
+	return nil
+}
+
+func writePoint(fw *fastio.FastWriter, v *Point, sep bool) error {
+	if sep {
+		if err := fw.WriteByte(' '); err != nil {
+			return err
+		}
+	}
+	if err := fw.WriteInt64(int64(v.X)); err != nil {
+		return err
+	}
+	if err := fw.WriteByte(' '); err != nil {
+		return err
+	}
+	if err := fw.WriteInt64(int64(v.Y)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// fastioOrderEOF превращает io.EOF посреди записи в io.ErrUnexpectedEOF.
+func fastioOrderEOF(err error, first bool) error {
+	if err == io.EOF && !first {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// fastioOrderGrow возвращает s длины i+1 для чтения i-го из n элементов.
+// n приходит из входа, поэтому память выделяется порциями по мере
+// чтения элементов, а не сразу под n.
+func fastioOrderGrow[S ~[]E, E any](s S, i, n int) S {
+	if i == cap(s) {
+		s = slices.Grow(s[:i], min(n-i, max(i, 1024)))
+	}
+	return s[:i+1]
+}
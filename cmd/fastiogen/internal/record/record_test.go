@@ -0,0 +1,158 @@
+package record
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/PavelKhromykhGo/fastio/fastio"
+)
+
+func testOrders() []Order {
+	return []Order{
+		{
+			ID: 7, First: -1, Name: "ann", Label: "vip", Score: 9.75, Ratio: 0.1, Active: true,
+			Count: -42, Big: math.MinInt64, Mask: math.MaxUint64, Small: -128, Flags: 65535,
+			Pos: Point{1, -2}, Tags: [2]string{"a", "b"}, N: 3, Items: []int32{1, -2, 3},
+			Path: []Point{{5, 6}, {7, 8}}, Raw: []byte("raw"), Grid: [][]uint8{[]byte("ab"), []byte("cd")},
+		},
+		{
+			// Пустые срезы читаются как nil.
+			ID: 8, Name: "bob", Label: "x", Score: 1e-7, Tags: [2]string{"c", "d"}, Raw: []byte("y"),
+		},
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	fw := fastio.NewWriter(&buf)
+	for _, o := range testOrders() {
+		o.Cache = "not written"
+		if err := WriteOrder(fw, o); err != nil {
+			t.Fatalf("WriteOrder error: %v", err)
+		}
+	}
+	if err := fw.Flush(); err != nil {
+		t.Fatalf("Flush error: %v", err)
+	}
+
+	fr := fastio.NewReader(strings.NewReader(buf.String()))
+	for i, want := range testOrders() {
+		got, err := ReadOrder(fr)
+		if err != nil {
+			t.Fatalf("ReadOrder #%d error: %v", i, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("ReadOrder #%d = %+v; want %+v", i, got, want)
+		}
+	}
+	if _, err := ReadOrder(fr); !errors.Is(err, io.EOF) {
+		t.Fatalf("Expected EOF error, got: %v", err)
+	}
+
+	// Сгенерированный код читает то же, что и Decode.
+	fr = fastio.NewReader(strings.NewReader(buf.String()))
+	for i, want := range testOrders() {
+		var got Order
+		if err := fr.Decode(&got); err != nil {
+			t.Fatalf("Decode #%d error: %v", i, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("Decode #%d = %+v; want %+v", i, got, want)
+		}
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	if _, err := ReadOrder(fastio.NewReader(strings.NewReader("7 1 ann"))); err != io.ErrUnexpectedEOF {
+		t.Fatalf("Expected io.ErrUnexpectedEOF, got: %v", err)
+	}
+	_, err := ReadPoint(fastio.NewReader(strings.NewReader("1 40000")))
+	var pe *fastio.ParseError
+	if !errors.As(err, &pe) || !errors.Is(err, fastio.ErrRange) {
+		t.Fatalf("Expected ParseError with ErrRange, got: %v", err)
+	}
+}
+
+func TestHugeCount(t *testing.T) {
+	// Счётчик Path из входа не выделяет память заранее.
+	in := "0 1 a b 1 1 true 1 1 1 1 1 1 1 t1 t2 0 100000000000000000 1 2"
+	if _, err := ReadOrder(fastio.NewReader(strings.NewReader(in))); err != io.ErrUnexpectedEOF {
+		t.Fatalf("Expected io.ErrUnexpectedEOF, got: %v", err)
+	}
+}
+
+func TestWriteInvalidTokens(t *testing.T) {
+	// Пустой токен или токен с пробелом нельзя прочитать обратно,
+	// поэтому WriteOrder возвращает ошибку вместо записи.
+	bad := []func(o *Order){
+		func(o *Order) { o.Name = "" },
+		func(o *Order) { o.Label = "a b" },
+		func(o *Order) { o.Tags[1] = "x\ny" },
+		func(o *Order) { o.Raw = nil },
+		func(o *Order) { o.Grid = [][]uint8{[]byte("ok"), {}} },
+	}
+	for i, mutate := range bad {
+		o := testOrders()[0]
+		mutate(&o)
+		fw := fastio.NewWriter(io.Discard)
+		if err := WriteOrder(fw, o); !errors.Is(err, fastio.ErrInvalidToken) {
+			t.Errorf("case %d: WriteOrder error = %v; want ErrInvalidToken", i, err)
+		}
+	}
+}
+
+func TestWriteLenMismatch(t *testing.T) {
+	// Items с тегом len=N, длина которого не равна N, прочиталась бы
+	// неверно: WriteOrder возвращает ошибку и ничего не пишет.
+	for _, items := range [][]int32{{1, 2}, {1, 2, 3, 4}, nil} {
+		o := testOrders()[0]
+		o.Items = items
+		var buf bytes.Buffer
+		fw := fastio.NewWriter(&buf)
+		if err := WriteOrder(fw, o); !errors.Is(err, fastio.ErrLenMismatch) {
+			t.Errorf("Items %v: WriteOrder error = %v; want ErrLenMismatch", items, err)
+		}
+		if err := fw.Flush(); err != nil || buf.Len() != 0 {
+			t.Errorf("Items %v: wrote %q, Flush error %v; want nothing", items, buf.String(), err)
+		}
+	}
+}
+
+func TestBatchRoundTrip(t *testing.T) {
+	// Batch сгенерирован отдельным запуском fastiogen в тот же пакет.
+	batches := []Batch{
+		{Name: "a", N: 3, Values: []int64{1, -2, 3}, Tags: []string{"x", "y"}},
+		{Name: "b"},
+	}
+	var buf bytes.Buffer
+	fw := fastio.NewWriter(&buf)
+	for _, b := range batches {
+		if err := WriteBatch(fw, b); err != nil {
+			t.Fatalf("WriteBatch error: %v", err)
+		}
+	}
+	if err := WriteBatch(fw, Batch{Name: "c", N: 1}); !errors.Is(err, fastio.ErrLenMismatch) {
+		t.Fatalf("WriteBatch with N = 1 and no values: error = %v; want ErrLenMismatch", err)
+	}
+	if err := fw.Flush(); err != nil {
+		t.Fatalf("Flush error: %v", err)
+	}
+	fr := fastio.NewReader(strings.NewReader(buf.String()))
+	for i, want := range batches {
+		got, err := ReadBatch(fr)
+		if err != nil {
+			t.Fatalf("ReadBatch #%d error: %v", i, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("ReadBatch #%d = %+v; want %+v", i, got, want)
+		}
+	}
+	if _, err := ReadBatch(fr); err != io.EOF {
+		t.Fatalf("Expected io.EOF, got: %v", err)
+	}
+}
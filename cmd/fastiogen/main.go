@@ -0,0 +1,69 @@
+// Fastiogen генерирует специализированные функции чтения и записи
+// записей для структур, по тем же правилам, что и FastReader.Decode,
+// но без рефлексии: сгенерированный код напрямую вызывает NextInt64,
+// NextWord, WriteInt64 и т. д.
+//
+// Запуск через go generate:
+//
+//	//go:generate go run github.com/PavelKhromykhGo/fastio/cmd/fastiogen -type=Order
+//
+// Для типа Order создаются функции
+//
+//	func ReadOrder(fr *fastio.FastReader) (Order, error)
+//	func WriteOrder(fw *fastio.FastWriter, v Order) error
+//
+// WriteOrder пишет поля через пробел и завершает запись '\n', поэтому
+// ReadOrder читает её обратно. Строки и []byte пишутся через
+// WriteToken: пустое значение или значение с пробельным символом
+// не записывается, а возвращается fastio.ErrInvalidToken, так как
+// прочитать его обратно нельзя. По той же причине срез с тегом len=Field,
+// длина которого не равна Field, даёт fastio.ErrLenMismatch до записи
+// первого поля. Вложенные структуры пакета получают неэкспортируемые
+// readX/writeX. Несколько типов пакета перечисляются в одном -type через
+// запятую, чтобы общие вложенные типы не генерировались дважды; запуски
+// для типов без общих вложенных структур могут писать в разные файлы
+// одного пакета — вспомогательные функции файла называются по первому
+// типу (fastioOrderEOF и т. п.).
+//
+// Флаги:
+//
+//	-type    имена структур через запятую (обязателен);
+//	-output  имя выходного файла (по умолчанию <type>_fastio.go).
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+)
+
+func main() {
+	log.SetPrefix("fastiogen: ")
+	log.SetFlags(0)
+
+	typeNames := flag.String("type", "", "comma-separated list of struct type names")
+	output := flag.String("output", "", "output file name; default <type>_fastio.go")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: fastiogen -type=T[,T...] [-output file] [dir]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if *typeNames == "" || flag.NArg() > 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	dir := "."
+	if flag.NArg() == 1 {
+		dir = flag.Arg(0)
+	}
+	types := strings.Split(*typeNames, ",")
+	if *output == "" {
+		*output = strings.ToLower(types[0]) + "_fastio.go"
+	}
+
+	if err := run(dir, types, *output); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,42 @@
+package fastio
+
+// parseInteger разбирает b целиком как десятичное целое число
+// с необязательным знаком ('-' только при signed).
+// Возвращает модуль, не превышающий max (max+1 для отрицательных),
+// и признак отрицательного знака.
+//
+// Ошибки: ErrNoDigits — нет ни одной цифры, ErrSyntax — после цифр
+// встретились посторонние символы, ErrRange — переполнение.
+func parseInteger(b []byte, signed bool, max uint64) (uint64, bool, error) {
+	neg := false
+	limit := max
+	if len(b) > 0 && (b[0] == '+' || (b[0] == '-' && signed)) {
+		if b[0] == '-' {
+			neg = true
+			limit++
+		}
+		b = b[1:]
+	}
+	if len(b) == 0 || b[0] < '0' || b[0] > '9' {
+		return 0, false, ErrNoDigits
+	}
+
+	cutoff, cutlim := limit/10, byte(limit%10)
+	var val uint64
+	overflow := false
+	for _, c := range b {
+		c -= '0'
+		if c > 9 {
+			return 0, false, ErrSyntax
+		}
+		if val > cutoff || (val == cutoff && c > cutlim) {
+			overflow = true
+			continue
+		}
+		val = val*10 + uint64(c)
+	}
+	if overflow {
+		return 0, false, ErrRange
+	}
+	return val, neg, nil
+}
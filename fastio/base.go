@@ -0,0 +1,239 @@
+package fastio
+
+import (
+	"errors"
+	"io"
+	"math"
+	"strconv"
+)
+
+// ErrInvalidBase возвращается методами *Base для основания вне 2..36
+// (и, для чтения, отличного от 0).
+var ErrInvalidBase = errors.New("invalid base")
+
+// digitValue возвращает значение цифры b в основаниях до 36
+// или 36, если b не является цифрой.
+func digitValue(b byte) byte {
+	switch {
+	case '0' <= b && b <= '9':
+		return b - '0'
+	case 'a' <= b|0x20 && b|0x20 <= 'z':
+		return (b | 0x20) - 'a' + 10
+	}
+	return 36
+}
+
+// NextIntBase читает целое число со знаком в основании base (2..36).
+//
+// При base == 0 основание определяется по префиксу, как в
+// strconv.ParseInt: "0x" — 16, "0o" или ведущий "0" — 8, "0b" — 2,
+// иначе 10; в этом режиме между цифрами допускаются разделители '_'.
+// Буквенные цифры принимаются в любом регистре.
+//
+// Число должно заканчиваться разделителем или символом, который
+// не является буквой или цифрой: "0x1G" возвращает ErrSyntax.
+// Ошибки возвращаются как *ParseError с ErrNoDigits, ErrSyntax,
+// ErrRange или ErrInvalidBase.
+func (fr *FastReader) NextIntBase(base int) (int64, error) {
+	val, neg, err := fr.nextIntegerBase("NextIntBase", base, true, math.MaxInt64)
+	if err != nil {
+		return 0, err
+	}
+	if neg {
+		return int64(-val), nil
+	}
+	return int64(val), nil
+}
+
+// NextUintBase читает беззнаковое целое число в основании base.
+// Правила те же, что у NextIntBase, но знак '-' не допускается.
+func (fr *FastReader) NextUintBase(base int) (uint64, error) {
+	val, _, err := fr.nextIntegerBase("NextUintBase", base, false, math.MaxUint64)
+	return val, err
+}
+
+// nextIntegerBase — ядро NextIntBase и NextUintBase. Первые maxTokenPreview
+// прочитанных байт собираются в fr.tok для диагностики; аллокаций нет,
+// пока не произошла ошибка. Токен длиннее WithMaxTokenSize даёт
+// ErrTokenTooLong.
+func (fr *FastReader) nextIntegerBase(fn string, base int, signed bool, max uint64) (uint64, bool, error) {
+	if base != 0 && (base < 2 || base > 36) {
+		return 0, false, fr.parseError(fn, fr.offset(), "", ErrInvalidBase)
+	}
+	if err := fr.SkipSpaces(); err != nil {
+		return 0, false, err
+	}
+	b, err := fr.PeekByte()
+	if err != nil {
+		return 0, false, err
+	}
+	start := fr.offset()
+	fr.tok = fr.tok[:0]
+
+	neg := false
+	limit := max
+	if b == '+' || (b == '-' && signed) {
+		fr.consumeTok(b)
+		if b == '-' {
+			neg = true
+			limit++
+		}
+	}
+
+	// sawDigit — была ли цифра (или префикс основания) непосредственно перед
+	// текущей позицией: '_' допускается только после неё и только при base == 0.
+	sawDigit := false
+	digits := 0
+	var val uint64
+	if base == 0 {
+		base = 10
+		if b, err := fr.PeekByte(); err == nil && b == '0' {
+			fr.consumeTok(b)
+			base = 8
+			sawDigit = true
+			digits++
+			if p, err := fr.PeekByte(); err == nil {
+				switch p | 0x20 {
+				case 'x':
+					base = 16
+				case 'o':
+				case 'b':
+					base = 2
+				default:
+					p = 0
+				}
+				if p != 0 {
+					fr.consumeTok(p)
+					digits = 0
+				}
+			}
+		}
+		return fr.scanDigits(fn, start, uint64(base), true, sawDigit, digits, val, neg, limit)
+	}
+	return fr.scanDigits(fn, start, uint64(base), false, sawDigit, digits, val, neg, limit)
+}
+
+func (fr *FastReader) consumeTok(b byte) {
+	_, _ = fr.ReadByte()
+	if len(fr.tok) < maxTokenPreview {
+		fr.tok = append(fr.tok, b)
+	}
+}
+
+func (fr *FastReader) scanDigits(fn string, start int64, base uint64, underscores, sawDigit bool, digits int, val uint64, neg bool, limit uint64) (uint64, bool, error) {
+	cutoff := limit / base
+	overflow, underscore := false, false
+	for {
+		if fr.tokenExceeded(start) {
+			return 0, false, fr.tokenTooLong(fn, start, fr.tok, fr.maxToken, false)
+		}
+		b, err := fr.PeekByte()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return 0, false, err
+		}
+		if b == '_' && underscores && sawDigit {
+			fr.consumeTok(b)
+			sawDigit, underscore = false, true
+			continue
+		}
+		d := digitValue(b)
+		if uint64(d) >= base {
+			break
+		}
+		fr.consumeTok(b)
+		sawDigit, underscore = true, false
+		digits++
+		if overflow {
+			continue
+		}
+		if val > cutoff || val*base > limit-uint64(d) {
+			overflow = true
+			continue
+		}
+		val = val*base + uint64(d)
+	}
+
+	// Число должно заканчиваться не буквой, не цифрой и не '_'.
+	syntax := underscore
+	for {
+		if fr.tokenExceeded(start) {
+			return 0, false, fr.tokenTooLong(fn, start, fr.tok, fr.maxToken, false)
+		}
+		b, err := fr.PeekByte()
+		if err != nil || (digitValue(b) == 36 && b != '_') {
+			break
+		}
+		fr.consumeTok(b)
+		syntax = true
+	}
+
+	switch {
+	case syntax:
+		return 0, false, fr.parseError(fn, start, string(fr.tok), ErrSyntax)
+	case digits == 0:
+		return 0, false, fr.parseError(fn, start, string(fr.tok), ErrNoDigits)
+	case overflow:
+		return 0, false, fr.parseError(fn, start, string(fr.tok), ErrRange)
+	}
+	return val, neg, nil
+}
+
+// IntFormat задаёт оформление чисел для WriteIntBase и WriteUintBase.
+type IntFormat struct {
+	// Prefix добавляет префикс "0b", "0o" или "0x" для оснований 2, 8 и 16.
+	Prefix bool
+	// Width — минимальное число цифр; недостающие дополняются нулями
+	// после знака и префикса.
+	Width int
+}
+
+// WriteIntBase записывает v в основании base (2..36) строчными буквами,
+// например WriteIntBase(-255, 16, IntFormat{Prefix: true, Width: 4})
+// пишет "-0x00ff". Для основания вне 2..36 возвращает ErrInvalidBase.
+func (fw *FastWriter) WriteIntBase(v int64, base int, f IntFormat) error {
+	if v < 0 {
+		return fw.writeUintBase(uint64(-v), true, base, f)
+	}
+	return fw.writeUintBase(uint64(v), false, base, f)
+}
+
+// WriteUintBase записывает v в основании base (2..36), см. WriteIntBase.
+func (fw *FastWriter) WriteUintBase(v uint64, base int, f IntFormat) error {
+	return fw.writeUintBase(v, false, base, f)
+}
+
+func (fw *FastWriter) writeUintBase(mag uint64, neg bool, base int, f IntFormat) error {
+	if base < 2 || base > 36 {
+		return ErrInvalidBase
+	}
+	s := fw.scratch[:0]
+	if neg {
+		s = append(s, '-')
+	}
+	if f.Prefix {
+		switch base {
+		case 2:
+			s = append(s, "0b"...)
+		case 8:
+			s = append(s, "0o"...)
+		case 16:
+			s = append(s, "0x"...)
+		}
+	}
+	digitsStart := len(s)
+	s = strconv.AppendUint(s, mag, base)
+	if pad := f.Width - (len(s) - digitsStart); pad > 0 {
+		for i := 0; i < pad; i++ {
+			s = append(s, '0')
+		}
+		copy(s[digitsStart+pad:], s[digitsStart:len(s)-pad])
+		for i := digitsStart; i < digitsStart+pad; i++ {
+			s[i] = '0'
+		}
+	}
+	fw.scratch = s
+	return fw.WriteBytes(s)
+}
@@ -0,0 +1,121 @@
+package fastio
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestNextIntBaseMatchesStrconv(t *testing.T) {
+	cases := []struct {
+		in   string
+		base int
+	}{
+		{"ff", 16}, {"-FF", 16}, {"+7fffffffffffffff", 16}, {"-8000000000000000", 16},
+		{"8000000000000000", 16}, {"101101", 2}, {"zz", 36}, {"777", 8}, {"19", 8},
+		{"0x1F", 0}, {"-0b1010", 0}, {"0o17", 0}, {"017", 0}, {"0", 0}, {"-0", 0},
+		{"1_000_000", 0}, {"0x_ff", 0}, {"1__0", 0}, {"10_", 0}, {"_10", 0}, {"0x", 0},
+		{"09", 0}, {"0x1g", 0}, {"12abc", 10}, {"1_0", 10}, {"-", 10},
+		{"9223372036854775808", 0}, {"-9223372036854775808", 0},
+	}
+	for _, tc := range cases {
+		want, wantErr := strconv.ParseInt(tc.in, tc.base, 64)
+		if wantErr != nil {
+			want = 0
+		}
+		for _, r := range []*FastReader{
+			newTestReader(tc.in + " 1"),
+			NewReader(iotest.OneByteReader(strings.NewReader(tc.in + " 1"))),
+		} {
+			got, err := r.NextIntBase(tc.base)
+			if (err != nil) != (wantErr != nil) || got != want {
+				t.Fatalf("NextIntBase(%q, %d) = %d, %v; want %d, %v", tc.in, tc.base, got, err, want, wantErr)
+			}
+			if errors.Is(wantErr, strconv.ErrRange) && !errors.Is(err, ErrRange) {
+				t.Fatalf("NextIntBase(%q, %d): expected ErrRange, got: %v", tc.in, tc.base, err)
+			}
+			// Ошибочный токен поглощается целиком, чтение продолжается дальше.
+			if v, err := r.NextInt(); err != nil || v != 1 {
+				t.Fatalf("NextInt after %q = %d, %v; want 1", tc.in, v, err)
+			}
+		}
+	}
+}
+
+func TestNextUintBase(t *testing.T) {
+	r := newTestReader("ffffffffffffffff 10000000000000000 -1 0b11")
+	v, err := r.NextUintBase(16)
+	if err != nil || v != 1<<64-1 {
+		t.Fatalf("NextUintBase = %d, %v; want MaxUint64", v, err)
+	}
+	if _, err := r.NextUintBase(16); !errors.Is(err, ErrRange) {
+		t.Fatalf("Expected ErrRange, got: %v", err)
+	}
+	_, err = r.NextUintBase(10)
+	var pe *ParseError
+	if !errors.As(err, &pe) || !errors.Is(err, ErrNoDigits) {
+		t.Fatalf("Expected ParseError with ErrNoDigits, got: %v", err)
+	}
+	if pe.Func != "NextUintBase" || pe.Col != 36 {
+		t.Fatalf("ParseError = %+v; want NextUintBase at col 36", *pe)
+	}
+	// После ошибки '-' остаётся непрочитанным, за ним идёт "1".
+	if _, err := r.NextWord(); err != nil {
+		t.Fatalf("NextWord error: %v", err)
+	}
+	if v, err := r.NextUintBase(0); err != nil || v != 3 {
+		t.Fatalf("NextUintBase(0) = %d, %v; want 3", v, err)
+	}
+}
+
+func TestNextIntBaseInvalidBase(t *testing.T) {
+	r := newTestReader("10")
+	for _, base := range []int{1, 37, -2} {
+		if _, err := r.NextIntBase(base); !errors.Is(err, ErrInvalidBase) {
+			t.Fatalf("NextIntBase(%d): expected ErrInvalidBase, got: %v", base, err)
+		}
+	}
+	if v, err := r.NextIntBase(2); err != nil || v != 2 {
+		t.Fatalf("NextIntBase(2) = %d, %v; want 2", v, err)
+	}
+}
+
+func TestWriteIntBase(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	steps := []func() error{
+		func() error { return w.WriteIntBase(-255, 16, IntFormat{Prefix: true, Width: 4}) },
+		func() error { return w.WriteByte(' ') },
+		func() error { return w.WriteUintBase(5, 2, IntFormat{Width: 8}) },
+		func() error { return w.WriteByte(' ') },
+		func() error { return w.WriteUintBase(8, 8, IntFormat{Prefix: true}) },
+		func() error { return w.WriteByte(' ') },
+		func() error { return w.WriteIntBase(35, 36, IntFormat{Prefix: true, Width: 1}) },
+		func() error { return w.WriteByte(' ') },
+		func() error { return w.WriteIntBase(-1<<63, 2, IntFormat{}) },
+	}
+	for i, step := range steps {
+		if err := step(); err != nil {
+			t.Fatalf("step %d error: %v", i, err)
+		}
+	}
+	if err := w.WriteIntBase(1, 37, IntFormat{}); !errors.Is(err, ErrInvalidBase) {
+		t.Fatalf("Expected ErrInvalidBase, got: %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush error: %v", err)
+	}
+	want := "-0x00ff 00000101 0o10 z -1" + strings.Repeat("0", 63)
+	if buf.String() != want {
+		t.Fatalf("output = %q; want %q", buf.String(), want)
+	}
+
+	// Записанное читается обратно в автоматическом режиме.
+	r := newTestReader(strings.SplitN(want, " ", 2)[0])
+	if v, err := r.NextIntBase(0); err != nil || v != -255 {
+		t.Fatalf("NextIntBase(0) = %d, %v; want -255", v, err)
+	}
+}
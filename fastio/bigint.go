@@ -0,0 +1,171 @@
+package fastio
+
+import (
+	"errors"
+	"io"
+	"math/big"
+	"math/bits"
+)
+
+// bigChunkDigits — сколько десятичных цифр гарантированно помещается
+// в одно машинное слово big.Word (19 для 64-битных, 9 для 32-битных платформ).
+const bigChunkDigits = 9 + 10*(bits.UintSize/64)
+
+// pow10Word возвращает 10^n для n <= bigChunkDigits.
+func pow10Word(n int) big.Word {
+	p := big.Word(1)
+	for ; n > 0; n-- {
+		p *= 10
+	}
+	return p
+}
+
+var bigChunkPow = pow10Word(bigChunkDigits)
+
+// NextBigInt читает десятичное целое произвольной длины в dst.
+// Допускается ведущий '+' или '-'.
+//
+// Цифры разбираются прямо из буфера порциями по машинному слову
+// и накапливаются в памяти, уже принадлежащей dst, поэтому при повторном
+// использовании одного *big.Int аллокаций нет. При ошибке значение dst
+// не определено; отсутствие цифр возвращается как *ParseError с ErrNoDigits,
+// число длиннее WithMaxTokenSize — с ErrTokenTooLong.
+func (fr *FastReader) NextBigInt(dst *big.Int) error {
+	if err := fr.SkipSpaces(); err != nil {
+		return err
+	}
+	if fr.pos >= fr.n {
+		if err := fr.ensureData(); err != nil {
+			return err
+		}
+	}
+	start := fr.offset()
+	// Начало числа для ParseError: к моменту ошибки оно может уже
+	// покинуть буфер.
+	var head [maxTokenPreview]byte
+	hn := 0
+
+	var sign byte
+	if b := fr.buf[fr.pos]; b == '+' || b == '-' {
+		sign = b
+		head[0], hn = b, 1
+		fr.pos++
+	}
+
+	words := dst.Bits()[:0]
+	var acc big.Word
+	k := 0
+	digits := 0
+	for {
+		buf := fr.buf[:fr.n]
+		i := fr.pos
+		for i < len(buf) {
+			c := buf[i] - '0'
+			if c > 9 {
+				break
+			}
+			acc = acc*10 + big.Word(c)
+			if k++; k == bigChunkDigits {
+				words = mulAddWord(words, bigChunkPow, acc)
+				acc, k = 0, 0
+			}
+			i++
+		}
+		hn += copy(head[hn:], buf[fr.pos:i])
+		digits += i - fr.pos
+		fr.pos = i
+		if fr.tokenExceeded(start) {
+			return fr.tokenTooLong("NextBigInt", start, head[:hn], fr.maxToken, false)
+		}
+		if i < len(buf) {
+			break
+		}
+		if err := fr.ensureData(); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return err
+		}
+	}
+
+	if digits == 0 {
+		tok := fr.peekToken()
+		if sign != 0 {
+			tok = string(sign) + tok
+		}
+		return fr.parseError("NextBigInt", start, tok, ErrNoDigits)
+	}
+	if k > 0 {
+		words = mulAddWord(words, pow10Word(k), acc)
+	}
+	dst.SetBits(words)
+	if sign == '-' {
+		dst.Neg(dst)
+	}
+	return nil
+}
+
+// mulAddWord вычисляет z = z*m + a над младшими-первыми словами z.
+func mulAddWord(z []big.Word, m, a big.Word) []big.Word {
+	carry := uint(a)
+	for i, w := range z {
+		hi, lo := bits.Mul(uint(w), uint(m))
+		var c uint
+		lo, c = bits.Add(lo, carry, 0)
+		z[i] = big.Word(lo)
+		carry = hi + c
+	}
+	if carry != 0 {
+		z = append(z, big.Word(carry))
+	}
+	return z
+}
+
+// WriteBigInt записывает x в десятичном формате.
+//
+// Цифры строятся прямо в scratch-буфере делением копии x на 10^19
+// (10^9 на 32-битных платформах), без x.String() и промежуточных строк;
+// после прогрева буферов аллокаций нет.
+func (fw *FastWriter) WriteBigInt(x *big.Int) error {
+	if x.IsInt64() {
+		return fw.WriteInt64(x.Int64())
+	}
+	q := append(fw.words[:0], x.Bits()...)
+	fw.words = q
+
+	// Каждое слово даёт не больше bigChunkDigits+1 цифр, плюс знак.
+	n := len(q)*(bigChunkDigits+1) + 1
+	if cap(fw.scratch) < n {
+		fw.scratch = make([]byte, n)
+	}
+	s := fw.scratch[:n]
+	i := n
+	for len(q) > 0 {
+		r := divWord(q, bigChunkPow)
+		for len(q) > 0 && q[len(q)-1] == 0 {
+			q = q[:len(q)-1]
+		}
+		// Внутренние порции дополняются нулями до bigChunkDigits цифр.
+		for d := 0; d < bigChunkDigits && (r != 0 || len(q) > 0); d++ {
+			i--
+			s[i] = byte('0' + r%10)
+			r /= 10
+		}
+	}
+	if x.Sign() < 0 {
+		i--
+		s[i] = '-'
+	}
+	return fw.WriteBytes(s[i:])
+}
+
+// divWord делит z на d на месте и возвращает остаток.
+func divWord(z []big.Word, d big.Word) big.Word {
+	var r uint
+	for i := len(z) - 1; i >= 0; i-- {
+		var q uint
+		q, r = bits.Div(r, uint(z[i]), uint(d))
+		z[i] = big.Word(q)
+	}
+	return big.Word(r)
+}
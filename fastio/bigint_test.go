@@ -0,0 +1,113 @@
+package fastio
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/big"
+	"math/rand"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func randomDecimal(rng *rand.Rand, digits int) string {
+	b := make([]byte, digits)
+	for i := range b {
+		b[i] = byte('0' + rng.Intn(10))
+	}
+	if rng.Intn(2) == 0 {
+		return "-" + string(b)
+	}
+	return string(b)
+}
+
+func TestNextBigIntMatchesSetString(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	inputs := []string{"0", "-0", "+42", "000123", "9223372036854775807", "-9223372036854775808",
+		"18446744073709551616", "-340282366920938463463374607431768211456"}
+	for i := 0; i < 200; i++ {
+		inputs = append(inputs, randomDecimal(rng, 1+rng.Intn(600)))
+	}
+	in := strings.Join(inputs, "\n")
+
+	for _, r := range []*FastReader{
+		newTestReader(in),
+		NewReader(iotest.OneByteReader(strings.NewReader(in))),
+		NewReader(strings.NewReader(in), WithBufferSize(16)),
+	} {
+		var got big.Int
+		for _, s := range inputs {
+			if err := r.NextBigInt(&got); err != nil {
+				t.Fatalf("NextBigInt(%q) error: %v", s, err)
+			}
+			want, _ := new(big.Int).SetString(s, 10)
+			if got.Cmp(want) != 0 {
+				t.Fatalf("NextBigInt(%q) = %s", s, got.String())
+			}
+		}
+	}
+}
+
+func TestNextBigIntNoDigits(t *testing.T) {
+	r := newTestReader("12 -x")
+	var v big.Int
+	if err := r.NextBigInt(&v); err != nil || v.Int64() != 12 {
+		t.Fatalf("NextBigInt = %s, %v; want 12", v.String(), err)
+	}
+	err := r.NextBigInt(&v)
+	var pe *ParseError
+	if !errors.As(err, &pe) || !errors.Is(err, ErrNoDigits) {
+		t.Fatalf("Expected ParseError with ErrNoDigits, got: %v", err)
+	}
+	if pe.Token != "-x" || pe.Col != 4 {
+		t.Fatalf("ParseError = %+v; want token -x at col 4", *pe)
+	}
+}
+
+func TestWriteBigInt(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	var want strings.Builder
+	values := []string{"0", "-1", "10000000000000000000", "-10000000000000000000000000000000000000",
+		"100000000000000000000000000000000000000000000000000000001"}
+	for i := 0; i < 200; i++ {
+		values = append(values, randomDecimal(rng, 1+rng.Intn(600)))
+	}
+	for _, s := range values {
+		x, _ := new(big.Int).SetString(s, 10)
+		if err := w.WriteBigInt(x); err != nil {
+			t.Fatalf("WriteBigInt error: %v", err)
+		}
+		if err := w.WriteByte('\n'); err != nil {
+			t.Fatalf("WriteByte error: %v", err)
+		}
+		want.WriteString(x.String())
+		want.WriteByte('\n')
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush error: %v", err)
+	}
+	if buf.String() != want.String() {
+		t.Fatalf("WriteBigInt output mismatch:\ngot  %q\nwant %q", buf.String(), want.String())
+	}
+}
+
+func TestBigIntNoAllocs(t *testing.T) {
+	num := randomDecimal(rand.New(rand.NewSource(3)), 500)
+	r := newTestReader(strings.Repeat(num+" ", 2000))
+	w := NewWriter(io.Discard)
+	var v big.Int
+	allocs := testing.AllocsPerRun(1000, func() {
+		if err := r.NextBigInt(&v); err != nil {
+			t.Fatalf("NextBigInt error: %v", err)
+		}
+		if err := w.WriteBigInt(&v); err != nil {
+			t.Fatalf("WriteBigInt error: %v", err)
+		}
+	})
+	if allocs != 0 {
+		t.Fatalf("NextBigInt/WriteBigInt allocs = %v; want 0", allocs)
+	}
+}
@@ -0,0 +1,104 @@
+package fastio
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// WithContext привязывает FastReader или FastWriter к ctx: после отмены
+// ctx или истечения его дедлайна чтение из io.Reader (fill) и Flush
+// возвращают ctx.Err(), которая сохраняется как ошибка Err().
+//
+// Если io.Reader поддерживает SetReadDeadline (net.Conn, os.File для
+// каналов), а io.Writer — SetWriteDeadline, отмена прерывает уже
+// заблокированный вызов: дедлайн переводится в прошлое, а после возврата
+// сбрасывается в нулевое значение. Иначе контекст проверяется только
+// перед каждым вызовом Read и Write.
+func WithContext(ctx context.Context) Option {
+	return func(o *options) {
+		o.ctx = ctx
+	}
+}
+
+// NewReaderContext создаёт FastReader, чтение которого отменяется вместе
+// с ctx. Эквивалентно NewReader(r, WithContext(ctx), opts...).
+func NewReaderContext(ctx context.Context, r io.Reader, opts ...Option) *FastReader {
+	return NewReader(r, append([]Option{WithContext(ctx)}, opts...)...)
+}
+
+// NewWriterContext создаёт FastWriter, Flush которого отменяется вместе
+// с ctx. Эквивалентно NewWriter(w, WithContext(ctx), opts...).
+func NewWriterContext(ctx context.Context, w io.Writer, opts ...Option) *FastWriter {
+	return NewWriter(w, append([]Option{WithContext(ctx)}, opts...)...)
+}
+
+// aLongTimeAgo — дедлайн в прошлом, немедленно прерывающий операцию.
+var aLongTimeAgo = time.Unix(1, 0)
+
+// readDeadline и writeDeadline возвращают SetReadDeadline и SetWriteDeadline
+// для v, если контекст может быть отменён и v их поддерживает.
+func readDeadline(ctx context.Context, v any) func(time.Time) error {
+	if d, ok := v.(interface{ SetReadDeadline(time.Time) error }); ok && ctx != nil && ctx.Done() != nil {
+		return d.SetReadDeadline
+	}
+	return nil
+}
+
+func writeDeadline(ctx context.Context, v any) func(time.Time) error {
+	if d, ok := v.(interface{ SetWriteDeadline(time.Time) error }); ok && ctx != nil && ctx.Done() != nil {
+		return d.SetWriteDeadline
+	}
+	return nil
+}
+
+// interruptible выполняет блокирующую операцию op. Если ctx отменяется
+// до её завершения, setDeadline прерывает её дедлайном в прошлом;
+// результат сообщает, что это произошло и ошибку op нужно заменить
+// на ctx.Err(). При setDeadline == nil op просто выполняется.
+func interruptible(ctx context.Context, setDeadline func(time.Time) error, op func()) bool {
+	if setDeadline == nil {
+		op()
+		return false
+	}
+	done := make(chan struct{})
+	stop := context.AfterFunc(ctx, func() {
+		_ = setDeadline(aLongTimeAgo)
+		close(done)
+	})
+	op()
+	if stop() {
+		return false
+	}
+	<-done
+	_ = setDeadline(time.Time{})
+	return true
+}
+
+// read вызывает r.Read с учётом контекста ридера.
+func (fr *FastReader) read(p []byte) (n int, err error) {
+	if fr.ctx == nil {
+		return fr.r.Read(p)
+	}
+	if err := fr.ctx.Err(); err != nil {
+		return 0, err
+	}
+	if interruptible(fr.ctx, fr.deadline, func() { n, err = fr.r.Read(p) }) {
+		err = fr.ctx.Err()
+	}
+	return n, err
+}
+
+// write вызывает w.Write с учётом контекста writer'а.
+func (fw *FastWriter) write(p []byte) (n int, err error) {
+	if fw.ctx == nil {
+		return fw.w.Write(p)
+	}
+	if err := fw.ctx.Err(); err != nil {
+		return 0, err
+	}
+	if interruptible(fw.ctx, fw.deadline, func() { n, err = fw.w.Write(p) }) {
+		err = fw.ctx.Err()
+	}
+	return n, err
+}
@@ -0,0 +1,152 @@
+package fastio
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReaderContextCancelsBlockedRead(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r := NewReaderContext(ctx, client)
+	go func() {
+		server.Write([]byte("42 "))
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	if v, err := r.NextInt(); err != nil || v != 42 {
+		t.Fatalf("NextInt = %d, %v; want 42", v, err)
+	}
+	if _, err := r.NextInt(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("NextInt error = %v; want context.Canceled", err)
+	}
+	if !errors.Is(r.Err(), context.Canceled) {
+		t.Errorf("Err() = %v; want context.Canceled", r.Err())
+	}
+
+	// Дедлайн соединения сброшен: его можно читать дальше.
+	go server.Write([]byte("x"))
+	buf := make([]byte, 1)
+	if _, err := client.Read(buf); err != nil {
+		t.Errorf("conn read after cancel: %v", err)
+	}
+}
+
+func TestReaderContextDeadline(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	r := NewReader(client, WithContext(ctx))
+	if _, err := r.NextLine(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("NextLine error = %v; want context.DeadlineExceeded", err)
+	}
+}
+
+func TestReaderContextWithoutDeadlines(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := NewReaderContext(ctx, strings.NewReader("1 2 "), WithBufferSize(16))
+	if v, err := r.NextInt(); err != nil || v != 1 {
+		t.Fatalf("NextInt = %d, %v; want 1", v, err)
+	}
+	cancel()
+	// Уже прочитанные данные остаются доступны, ошибка появляется
+	// при следующем обращении к io.Reader.
+	if v, err := r.NextInt(); err != nil || v != 2 {
+		t.Fatalf("NextInt = %d, %v; want 2", v, err)
+	}
+	if _, err := r.NextInt(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("NextInt error = %v; want context.Canceled", err)
+	}
+
+	r = NewReaderContext(ctx, strings.NewReader("1"))
+	if _, err := r.NextInt(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("NextInt on canceled context = %v; want context.Canceled", err)
+	}
+}
+
+// pipeConn — net.Conn, у которого запись пустого среза не блокируется.
+type pipeConn struct {
+	net.Conn
+}
+
+func (c pipeConn) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	return c.Conn.Write(p)
+}
+
+func TestWriterContextCancelsBlockedFlush(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w := NewWriterContext(ctx, pipeConn{client})
+	if err := w.WriteString("nobody reads this\n"); err != nil {
+		t.Fatalf("WriteString error: %v", err)
+	}
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	if err := w.Flush(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Flush error = %v; want context.Canceled", err)
+	}
+	if err := w.WriteInt(1); !errors.Is(err, context.Canceled) {
+		t.Errorf("WriteInt after cancel = %v; want sticky context.Canceled", err)
+	}
+	if !errors.Is(w.Err(), context.Canceled) {
+		t.Errorf("Err() = %v; want context.Canceled", w.Err())
+	}
+}
+
+func TestWriterContextCanceledBeforeFlush(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var out strings.Builder
+	w := NewWriterContext(ctx, &out)
+	w.WriteString("data")
+	cancel()
+	if err := w.Flush(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Flush error = %v; want context.Canceled", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("written %q after cancel", out.String())
+	}
+}
+
+func TestContextNotCanceled(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		w := NewWriterContext(ctx, pipeConn{server})
+		for i := 0; i < 1000; i++ {
+			w.WriteInt(i)
+			w.WriteByte(' ')
+		}
+		w.Flush()
+		server.Close()
+	}()
+	r := NewReaderContext(ctx, client, WithBufferSize(64))
+	for i := 0; i < 1000; i++ {
+		if v, err := r.NextInt(); err != nil || v != i {
+			t.Fatalf("NextInt = %d, %v; want %d", v, err, i)
+		}
+	}
+	if _, err := r.NextInt(); err != io.EOF {
+		t.Errorf("NextInt at end = %v; want io.EOF", err)
+	}
+}
@@ -0,0 +1,522 @@
+package fastio
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+)
+
+var (
+	// ErrQuote возвращается CSVReader для незакрытой кавычки
+	// или лишних символов после закрывающей кавычки.
+	ErrQuote = errors.New(`extraneous or missing " in quoted field`)
+	// ErrBareQuote возвращается CSVReader для кавычки внутри поля без кавычек.
+	ErrBareQuote = errors.New(`bare " in non-quoted field`)
+	// ErrNoField возвращается типизированными методами CSVReader
+	// при обращении к несуществующему полю.
+	ErrNoField = errors.New("no such field")
+	// ErrInvalidSeparator возвращается SetSeparator для недопустимого разделителя.
+	ErrInvalidSeparator = errors.New("invalid separator")
+)
+
+// CSVReader читает записи CSV/TSV (RFC 4180) поверх FastReader.
+//
+// Поддерживаются поля в кавычках, экранированные кавычки (""),
+// переводы строк внутри полей в кавычках, окончания строк LF и CRLF.
+// Как и в encoding/csv, "\r\n" внутри поля в кавычках возвращается
+// как "\n". Пустые строки пропускаются.
+//
+// NextRecord не копирует данные: поля указывают во внутренний буфер
+// FastReader и действительны до следующего вызова NextRecord.
+// Копирование выполняется, только если запись пересекает границу буфера
+// или содержит экранированные кавычки либо "\r\n" внутри кавычек.
+//
+// CSVReader не является потокобезопасным.
+type CSVReader struct {
+	fr  *FastReader
+	sep byte
+
+	// rec — scratch-буфер для записей, пересекающих границу буфера FastReader,
+	// unq — для полей без экранирования; raw остаётся нетронутым для позиций ошибок.
+	rec    []byte
+	unq    []byte
+	raw    []byte
+	fields [][]byte
+	// starts[i] — смещение начала поля i внутри сырой записи.
+	starts []int
+
+	// recStart — смещение начала текущей записи во входе,
+	// recNL — число '\n', поглощённых вместе с записью.
+	recStart int64
+	recNL    int
+	// state — состояние поиска конца записи (см. scanRecord).
+	state csvState
+
+	header map[string]int
+}
+
+// NewCSVReader создаёт CSVReader поверх fr с разделителем ','.
+// Для TSV используйте SetSeparator('\t').
+func NewCSVReader(fr *FastReader) *CSVReader {
+	return &CSVReader{
+		fr:     fr,
+		sep:    ',',
+		fields: make([][]byte, 0, 16),
+		starts: make([]int, 0, 16),
+	}
+}
+
+// SetSeparator задаёт разделитель полей (например, ',' или '\t').
+// Кавычка, '\r' и '\n' не могут быть разделителем.
+func (c *CSVReader) SetSeparator(sep byte) error {
+	if sep == '"' || sep == '\r' || sep == '\n' {
+		return ErrInvalidSeparator
+	}
+	c.sep = sep
+	return nil
+}
+
+// NextRecord читает следующую запись и возвращает её поля.
+//
+// Возвращаемый срез и сами поля переиспользуются и действительны
+// только до следующего вызова NextRecord. Ошибки формата возвращаются
+// как *ParseError с ErrQuote или ErrBareQuote; по окончании ввода — io.EOF.
+//
+// Запись длиннее WithMaxLineSize (считаются байты всех её строк, включая
+// переводы строк внутри полей в кавычках) и поле длиннее WithMaxTokenSize
+// возвращаются как *ParseError с ErrTokenTooLong. Остаток такой записи
+// пропускается без сохранения при любом WithLongTokenMode, и следующий
+// вызов читает следующую запись.
+func (c *CSVReader) NextRecord() ([][]byte, error) {
+	c.fields = c.fields[:0]
+	c.starts = c.starts[:0]
+
+	raw, err := c.readRaw()
+	if err != nil {
+		return nil, err
+	}
+	err = c.splitFields(raw, nil)
+	if err == errNeedCopy {
+		c.unq = append(c.unq[:0], raw...)
+		c.fields = c.fields[:0]
+		c.starts = c.starts[:0]
+		err = c.splitFields(raw, c.unq)
+	}
+	if err == nil && c.fr.maxToken > 0 {
+		for i, f := range c.fields {
+			if len(f) > c.fr.maxToken {
+				err = c.recordError(raw, c.starts[i], ErrTokenTooLong)
+				break
+			}
+		}
+	}
+	if err != nil {
+		c.fields = c.fields[:0]
+		return nil, err
+	}
+	c.raw = raw
+	return c.fields, nil
+}
+
+// csvState — состояние поиска конца записи в readRaw.
+type csvState uint8
+
+const (
+	csvFieldStart csvState = iota // в начале поля
+	csvUnquoted                   // внутри поля без кавычек
+	csvQuoted                     // внутри поля в кавычках
+	csvAfterQuote                 // после кавычки внутри поля в кавычках
+)
+
+// readRaw возвращает байты следующей непустой записи без завершающего
+// перевода строки. Данные лежат в буфере FastReader или в c.rec.
+func (c *CSVReader) readRaw() (raw []byte, err error) {
+	fr := c.fr
+	for {
+		if err := fr.ensureData(); err != nil {
+			return nil, err
+		}
+		c.recStart = fr.offset()
+		c.state = csvFieldStart
+
+		start := fr.pos
+		if j, ok := c.scanRecord(fr.buf[start:fr.n]); ok {
+			fr.pos = start + j + 1
+			c.recNL = bytes.Count(fr.buf[start:start+j], []byte{'\n'}) + 1
+			raw = trimCR(fr.buf[start : start+j])
+			if len(raw) == 0 {
+				continue
+			}
+			if c.tooLong(j) {
+				return nil, c.positionError("NextRecord", raw, 0, preview(raw), ErrTokenTooLong)
+			}
+			return raw, nil
+		}
+		if fr.err != nil {
+			fr.pos = fr.n
+			c.recNL = bytes.Count(fr.buf[start:fr.n], []byte{'\n'})
+			raw = trimCR(fr.buf[start:fr.n])
+			if len(raw) == 0 {
+				continue
+			}
+			if c.tooLong(fr.n - start) {
+				return nil, c.positionError("NextRecord", raw, 0, preview(raw), ErrTokenTooLong)
+			}
+			return raw, nil
+		}
+		if c.tooLong(fr.n - start) {
+			fr.pos = fr.n
+			return nil, c.skipRecord(fr.buf[start:fr.n])
+		}
+
+		// Запись пересекает границу буфера: собираем её в c.rec
+		// до того, как fill() перезапишет buf.
+		c.rec = append(c.rec[:0], fr.buf[start:fr.n]...)
+		fr.pos = fr.n
+		for {
+			if err := fr.ensureData(); err != nil {
+				if errors.Is(err, io.EOF) {
+					c.recNL = bytes.Count(c.rec, []byte{'\n'})
+					if raw = trimCR(c.rec); len(raw) == 0 {
+						return nil, err
+					}
+					return raw, nil
+				}
+				return nil, err
+			}
+			start = fr.pos
+			j, ok := c.scanRecord(fr.buf[start:fr.n])
+			c.rec = append(c.rec, fr.buf[start:start+j]...)
+			if ok {
+				fr.pos = start + j + 1
+				c.recNL = bytes.Count(c.rec, []byte{'\n'}) + 1
+				if c.tooLong(len(c.rec)) {
+					return nil, c.positionError("NextRecord", c.rec, 0, preview(c.rec), ErrTokenTooLong)
+				}
+				raw = trimCR(c.rec)
+				if len(raw) == 0 {
+					break
+				}
+				return raw, nil
+			}
+			fr.pos = fr.n
+			if c.tooLong(len(c.rec)) {
+				return nil, c.skipRecord(c.rec)
+			}
+		}
+	}
+}
+
+// tooLong сообщает, что сырая запись из n байт длиннее WithMaxLineSize.
+func (c *CSVReader) tooLong(n int) bool {
+	return c.fr.maxLine > 0 && n > c.fr.maxLine
+}
+
+// skipRecord пропускает без сохранения остаток записи, превысившей
+// WithMaxLineSize, и возвращает *ParseError с ErrTokenTooLong. head —
+// уже просмотренное scanRecord начало записи, позиция FastReader
+// стоит сразу за ним.
+func (c *CSVReader) skipRecord(head []byte) error {
+	fr := c.fr
+	tok := preview(head)
+	nl := bytes.Count(head, []byte{'\n'})
+	for {
+		if err := fr.ensureData(); err != nil {
+			if !errors.Is(err, io.EOF) {
+				return err
+			}
+			break
+		}
+		start := fr.pos
+		if j, ok := c.scanRecord(fr.buf[start:fr.n]); ok {
+			nl += bytes.Count(fr.buf[start:start+j], []byte{'\n'}) + 1
+			fr.pos = start + j + 1
+			break
+		}
+		nl += bytes.Count(fr.buf[start:fr.n], []byte{'\n'})
+		fr.pos = fr.n
+	}
+	c.recNL = nl
+	return c.positionError("NextRecord", []byte(tok), 0, tok, ErrTokenTooLong)
+}
+
+// preview возвращает начало b для ParseError.Token.
+func preview(b []byte) string {
+	return string(b[:min(len(b), maxTokenPreview)])
+}
+
+// scanRecord ищет в b '\n', завершающий запись, и возвращает его индекс.
+// Перевод строки не завершает запись только внутри поля в кавычках;
+// поле считается таким, лишь если кавычка стоит в его начале, как
+// в encoding/csv, поэтому кавычка внутри поля (ErrBareQuote) не
+// захватывает следующие строки. Состояние c.state сохраняется между
+// вызовами для записей, пересекающих границу буфера.
+func (c *CSVReader) scanRecord(b []byte) (int, bool) {
+	i := 0
+	for i < len(b) {
+		switch c.state {
+		case csvQuoted:
+			k := bytes.IndexByte(b[i:], '"')
+			if k < 0 {
+				return len(b), false
+			}
+			i += k + 1
+			c.state = csvAfterQuote
+		case csvAfterQuote:
+			if b[i] == '"' {
+				c.state = csvQuoted
+				i++
+				continue
+			}
+			c.state = csvUnquoted
+		default:
+			seg := b[i:]
+			k := bytes.IndexByte(seg, '\n')
+			if k >= 0 {
+				seg = seg[:k]
+			}
+			q := bytes.IndexByte(seg, '"')
+			if q < 0 {
+				if k >= 0 {
+					return i + k, true
+				}
+				if seg[len(seg)-1] == c.sep {
+					c.state = csvFieldStart
+				} else {
+					c.state = csvUnquoted
+				}
+				return len(b), false
+			}
+			if q == 0 && c.state == csvFieldStart || q > 0 && seg[q-1] == c.sep {
+				c.state = csvQuoted
+			} else {
+				c.state = csvUnquoted
+			}
+			i += q + 1
+		}
+	}
+	return len(b), false
+}
+
+// errNeedCopy сообщает, что запись нужно скопировать в c.unq
+// перед удалением экранированных кавычек или '\r' из "\r\n".
+var errNeedCopy = errors.New("fastio: csv record needs copy")
+
+var crlf = []byte("\r\n")
+
+// splitFields разбивает сырую запись на поля. Поля в кавычках
+// с экранированными кавычками или "\r\n" сжимаются в dst — копии raw
+// той же длины; при dst == nil возвращается errNeedCopy. Поля указывают
+// в dst, если он задан, а raw не меняется.
+func (c *CSVReader) splitFields(raw, dst []byte) error {
+	sep := c.sep
+	out := raw
+	if dst != nil {
+		out = dst
+	}
+	i := 0
+	for {
+		c.starts = append(c.starts, i)
+		if i < len(raw) && raw[i] == '"' {
+			j := i + 1
+			w := j
+			for {
+				k := bytes.IndexByte(raw[j:], '"')
+				if k < 0 {
+					return c.recordError(raw, i, ErrQuote)
+				}
+				seg := raw[j : j+k]
+				if bytes.Contains(seg, crlf) {
+					// Как и encoding/csv, "\r\n" внутри кавычек
+					// возвращаем как "\n".
+					if dst == nil {
+						return errNeedCopy
+					}
+					for {
+						n := bytes.Index(seg, crlf)
+						if n < 0 {
+							break
+						}
+						w += copy(out[w:], seg[:n])
+						seg = seg[n+1:]
+					}
+					w += copy(out[w:], seg)
+				} else {
+					if w != j {
+						copy(out[w:], seg)
+					}
+					w += k
+				}
+				j += k + 1
+				if j < len(raw) && raw[j] == '"' {
+					if dst == nil {
+						return errNeedCopy
+					}
+					out[w] = '"'
+					w++
+					j++
+					continue
+				}
+				break
+			}
+			c.fields = append(c.fields, out[i+1:w])
+			if j == len(raw) {
+				return nil
+			}
+			if raw[j] != sep {
+				return c.recordError(raw, i, ErrQuote)
+			}
+			i = j + 1
+			continue
+		}
+
+		k := bytes.IndexByte(raw[i:], sep)
+		end := len(raw)
+		if k >= 0 {
+			end = i + k
+		}
+		field := out[i:end]
+		if bytes.IndexByte(field, '"') >= 0 {
+			return c.recordError(raw, i, ErrBareQuote)
+		}
+		c.fields = append(c.fields, field)
+		if k < 0 {
+			return nil
+		}
+		i = end + 1
+	}
+}
+
+// recordError строит *ParseError для поля, начинающегося с raw[start].
+func (c *CSVReader) recordError(raw []byte, start int, err error) error {
+	return c.positionError("NextRecord", raw, start, preview(raw[start:]), err)
+}
+
+func (c *CSVReader) positionError(fn string, raw []byte, start int, token string, err error) error {
+	p := c.fr.Position()
+	line := p.Line - c.recNL
+	col := start + 1
+	if before := raw[:start]; bytes.IndexByte(before, '\n') >= 0 {
+		line += bytes.Count(before, []byte{'\n'})
+		col = start - bytes.LastIndexByte(before, '\n')
+	}
+	if len(token) > maxTokenPreview {
+		token = token[:maxTokenPreview]
+	}
+	return &ParseError{
+		Func:   fn,
+		Offset: c.recStart + int64(start),
+		Line:   line,
+		Col:    col,
+		Token:  token,
+		Err:    err,
+	}
+}
+
+// ReadHeader читает следующую запись как заголовок и запоминает
+// соответствие имён колонок их индексам для Index и FieldByName.
+func (c *CSVReader) ReadHeader() ([]string, error) {
+	fields, err := c.NextRecord()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(fields))
+	c.header = make(map[string]int, len(fields))
+	for i, f := range fields {
+		names[i] = string(f)
+		if _, dup := c.header[names[i]]; !dup {
+			c.header[names[i]] = i
+		}
+	}
+	return names, nil
+}
+
+// Index возвращает индекс колонки name из заголовка или -1.
+func (c *CSVReader) Index(name string) int {
+	if i, ok := c.header[name]; ok {
+		return i
+	}
+	return -1
+}
+
+// NumFields возвращает число полей текущей записи.
+func (c *CSVReader) NumFields() int {
+	return len(c.fields)
+}
+
+// Field возвращает поле i текущей записи без копирования
+// или nil, если такого поля нет.
+func (c *CSVReader) Field(i int) []byte {
+	if i < 0 || i >= len(c.fields) {
+		return nil
+	}
+	return c.fields[i]
+}
+
+// FieldByName возвращает поле текущей записи по имени колонки из заголовка.
+func (c *CSVReader) FieldByName(name string) []byte {
+	return c.Field(c.Index(name))
+}
+
+// FieldString возвращает копию поля i в виде строки.
+func (c *CSVReader) FieldString(i int) string {
+	return string(c.Field(i))
+}
+
+// FieldInt разбирает поле i как int без промежуточных строк.
+// Поле должно целиком состоять из числа; ошибки возвращаются
+// как *ParseError с ErrNoDigits, ErrSyntax, ErrRange или ErrNoField.
+func (c *CSVReader) FieldInt(i int) (int, error) {
+	val, neg, err := c.fieldInteger("FieldInt", i, true, math.MaxInt)
+	if neg {
+		return int(-val), err
+	}
+	return int(val), err
+}
+
+// FieldInt64 разбирает поле i как int64.
+func (c *CSVReader) FieldInt64(i int) (int64, error) {
+	val, neg, err := c.fieldInteger("FieldInt64", i, true, math.MaxInt64)
+	if neg {
+		return int64(-val), err
+	}
+	return int64(val), err
+}
+
+// FieldUint64 разбирает поле i как uint64.
+func (c *CSVReader) FieldUint64(i int) (uint64, error) {
+	val, _, err := c.fieldInteger("FieldUint64", i, false, math.MaxUint64)
+	return val, err
+}
+
+// FieldFloat64 разбирает поле i как float64 по правилам NextFloat64.
+func (c *CSVReader) FieldFloat64(i int) (float64, error) {
+	if i < 0 || i >= len(c.fields) {
+		return 0, c.fieldError("FieldFloat64", i, ErrNoField)
+	}
+	v, err := atof64(c.fields[i])
+	if err != nil {
+		return 0, c.fieldError("FieldFloat64", i, err)
+	}
+	return v, nil
+}
+
+func (c *CSVReader) fieldInteger(fn string, i int, signed bool, max uint64) (uint64, bool, error) {
+	if i < 0 || i >= len(c.fields) {
+		return 0, false, c.fieldError(fn, i, ErrNoField)
+	}
+	val, neg, err := parseInteger(c.fields[i], signed, max)
+	if err != nil {
+		return 0, false, c.fieldError(fn, i, err)
+	}
+	return val, neg, nil
+}
+
+// fieldError строит *ParseError для поля i текущей записи.
+func (c *CSVReader) fieldError(fn string, i int, err error) error {
+	if i < 0 || i >= len(c.fields) {
+		return c.positionError(fn, c.raw, 0, "", err)
+	}
+	return c.positionError(fn, c.raw, c.starts[i], string(c.fields[i]), err)
+}
@@ -0,0 +1,134 @@
+package fastio
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+const benchCSVRows = 10000
+
+func makeCSVInput(rows int) []byte {
+	var sb strings.Builder
+	sb.WriteString("id,name,amount,comment\n")
+	for i := 0; i < rows; i++ {
+		sb.WriteString(strconv.Itoa(i))
+		sb.WriteString(",user")
+		sb.WriteString(strconv.Itoa(i % 97))
+		sb.WriteByte(',')
+		sb.WriteString(strconv.FormatFloat(float64(i)*1.25, 'f', 2, 64))
+		if i%10 == 0 {
+			sb.WriteString(",\"quoted, with comma\"\n")
+		} else {
+			sb.WriteString(",plain\n")
+		}
+	}
+	return []byte(sb.String())
+}
+
+func BenchmarkCSVReader_NextRecord(b *testing.B) {
+	data := makeCSVInput(benchCSVRows)
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		c := NewCSVReader(NewReader(bytes.NewReader(data)))
+		if _, err := c.ReadHeader(); err != nil {
+			b.Fatalf("ReadHeader error: %v", err)
+		}
+
+		sum := 0
+		for {
+			if _, err := c.NextRecord(); err != nil {
+				if err == io.EOF {
+					break
+				}
+				b.Fatalf("NextRecord error: %v", err)
+			}
+			id, err := c.FieldInt(0)
+			if err != nil {
+				b.Fatalf("FieldInt error: %v", err)
+			}
+			sum += id
+		}
+		_ = sum
+	}
+}
+
+func BenchmarkEncodingCSV_Read(b *testing.B) {
+	data := makeCSVInput(benchCSVRows)
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		cr := csv.NewReader(bytes.NewReader(data))
+		cr.ReuseRecord = true
+
+		sum := 0
+		if _, err := cr.Read(); err != nil {
+			b.Fatalf("Read header error: %v", err)
+		}
+		for {
+			rec, err := cr.Read()
+			if err != nil {
+				if err == io.EOF {
+					break
+				}
+				b.Fatalf("Read error: %v", err)
+			}
+			id, err := strconv.Atoi(rec[0])
+			if err != nil {
+				b.Fatalf("Atoi error: %v", err)
+			}
+			sum += id
+		}
+		_ = sum
+	}
+}
+
+func BenchmarkCSVWriter(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		c := NewCSVWriter(NewWriter(io.Discard))
+		for j := 0; j < benchCSVRows; j++ {
+			_ = c.Int(j)
+			_ = c.String("user")
+			_ = c.Float(float64(j)*1.25, 2)
+			_ = c.String("quoted, with comma")
+			if err := c.EndRecord(); err != nil {
+				b.Fatalf("EndRecord error: %v", err)
+			}
+		}
+		if err := c.Flush(); err != nil {
+			b.Fatalf("Flush error: %v", err)
+		}
+	}
+}
+
+func BenchmarkEncodingCSV_Write(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		cw := csv.NewWriter(io.Discard)
+		rec := make([]string, 4)
+		for j := 0; j < benchCSVRows; j++ {
+			rec[0] = strconv.Itoa(j)
+			rec[1] = "user"
+			rec[2] = strconv.FormatFloat(float64(j)*1.25, 'f', 2, 64)
+			rec[3] = "quoted, with comma"
+			if err := cw.Write(rec); err != nil {
+				b.Fatalf("Write error: %v", err)
+			}
+		}
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			b.Fatalf("Flush error: %v", err)
+		}
+	}
+}
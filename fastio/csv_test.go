@@ -0,0 +1,248 @@
+package fastio
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func readAllCSV(t *testing.T, c *CSVReader) [][]string {
+	t.Helper()
+	var out [][]string
+	for {
+		rec, err := c.NextRecord()
+		if errors.Is(err, io.EOF) {
+			return out
+		}
+		if err != nil {
+			t.Fatalf("NextRecord error: %v", err)
+		}
+		row := make([]string, len(rec))
+		for i, f := range rec {
+			row[i] = string(f)
+		}
+		out = append(out, row)
+	}
+}
+
+func TestCSVReaderMatchesEncodingCSV(t *testing.T) {
+	inputs := []string{
+		"a,b,c\n1,2,3\n",
+		"a,b,c\r\n1,2,3\r\n",
+		"\"quoted, comma\",\"with \"\"escaped\"\" quotes\",plain\n",
+		"\"multi\nline\",x\n\n\nnext,,\n",
+		"trailing,empty,\nlast,line,without,newline",
+		"\"\",\"\"\"\"\n",
+		",\n",
+		"\"a\r\nb\",c\r\n\"x\r\n\"\"y\"\"\r\n\r\nz\r\",\"\r\"\r\n",
+	}
+	for _, in := range inputs {
+		cr := csv.NewReader(strings.NewReader(in))
+		cr.FieldsPerRecord = -1
+		want, err := cr.ReadAll()
+		if err != nil {
+			t.Fatalf("encoding/csv error for %q: %v", in, err)
+		}
+		for _, r := range []*FastReader{
+			NewReader(strings.NewReader(in)),
+			NewReader(iotest.OneByteReader(strings.NewReader(in))),
+			NewReader(strings.NewReader(in), WithBufferSize(16)),
+		} {
+			got := readAllCSV(t, NewCSVReader(r))
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("CSV %q:\ngot  %q\nwant %q", in, got, want)
+			}
+		}
+	}
+}
+
+func TestCSVReaderTSVAndHeader(t *testing.T) {
+	c := NewCSVReader(newTestReader("id\tname\tscore\n1\talice\t9.5\n2\t\"bob \"\"b\"\"\"\t-3\n"))
+	if err := c.SetSeparator('\t'); err != nil {
+		t.Fatalf("SetSeparator error: %v", err)
+	}
+	header, err := c.ReadHeader()
+	if err != nil {
+		t.Fatalf("ReadHeader error: %v", err)
+	}
+	if !reflect.DeepEqual(header, []string{"id", "name", "score"}) {
+		t.Fatalf("ReadHeader = %q", header)
+	}
+	if c.Index("score") != 2 || c.Index("missing") != -1 {
+		t.Fatalf("Index mismatch: score=%d missing=%d", c.Index("score"), c.Index("missing"))
+	}
+
+	if _, err := c.NextRecord(); err != nil {
+		t.Fatalf("NextRecord error: %v", err)
+	}
+	id, err := c.FieldInt(c.Index("id"))
+	if err != nil || id != 1 {
+		t.Fatalf("FieldInt = %d, %v; want 1", id, err)
+	}
+	if name := string(c.FieldByName("name")); name != "alice" {
+		t.Fatalf("FieldByName = %q; want alice", name)
+	}
+	score, err := c.FieldFloat64(2)
+	if err != nil || score != 9.5 {
+		t.Fatalf("FieldFloat64 = %v, %v; want 9.5", score, err)
+	}
+
+	if _, err := c.NextRecord(); err != nil {
+		t.Fatalf("NextRecord error: %v", err)
+	}
+	if name := c.FieldString(1); name != `bob "b"` {
+		t.Fatalf("FieldString = %q; want %q", name, `bob "b"`)
+	}
+	v, err := c.FieldInt64(2)
+	if err != nil || v != -3 {
+		t.Fatalf("FieldInt64 = %d, %v; want -3", v, err)
+	}
+	if _, err := c.FieldUint64(2); !errors.Is(err, ErrNoDigits) {
+		t.Fatalf("FieldUint64 on negative: expected ErrNoDigits, got: %v", err)
+	}
+	if _, err := c.FieldInt(5); !errors.Is(err, ErrNoField) {
+		t.Fatalf("FieldInt out of range: expected ErrNoField, got: %v", err)
+	}
+
+	if _, err := c.NextRecord(); !errors.Is(err, io.EOF) {
+		t.Fatalf("Expected EOF error, got: %v", err)
+	}
+}
+
+func TestCSVReaderErrors(t *testing.T) {
+	c := NewCSVReader(newTestReader("ok,1\nbad\"quote,2\n"))
+	if _, err := c.NextRecord(); err != nil {
+		t.Fatalf("NextRecord error: %v", err)
+	}
+	_, err := c.NextRecord()
+	var pe *ParseError
+	if !errors.As(err, &pe) || !errors.Is(err, ErrBareQuote) {
+		t.Fatalf("Expected ParseError with ErrBareQuote, got: %v", err)
+	}
+	if pe.Line != 2 || pe.Col != 1 || pe.Offset != 5 {
+		t.Fatalf("ParseError position = %d:%d (offset %d); want 2:1 (offset 5)", pe.Line, pe.Col, pe.Offset)
+	}
+
+	c = NewCSVReader(newTestReader("x,\"multi\nline\",\"unterminated\n"))
+	_, err = c.NextRecord()
+	if !errors.As(err, &pe) || !errors.Is(err, ErrQuote) {
+		t.Fatalf("Expected ParseError with ErrQuote, got: %v", err)
+	}
+	if pe.Line != 2 || pe.Col != 7 {
+		t.Fatalf("ParseError position = %d:%d; want 2:7", pe.Line, pe.Col)
+	}
+
+	// "\r\n" в кавычках сжимается до "\n", но позиции ошибок не сдвигаются.
+	c = NewCSVReader(newTestReader("\"a\r\nb\"\"\",x\"\r\n\r\ny\"\n"))
+	_, err = c.NextRecord()
+	if !errors.As(err, &pe) || !errors.Is(err, ErrBareQuote) {
+		t.Fatalf("Expected ParseError with ErrBareQuote, got: %v", err)
+	}
+	if pe.Line != 2 || pe.Col != 6 || pe.Offset != 9 {
+		t.Fatalf("ParseError position = %d:%d (offset %d); want 2:6 (offset 9)", pe.Line, pe.Col, pe.Offset)
+	}
+
+	c = NewCSVReader(newTestReader("a,b\n1,x\n"))
+	_, _ = c.NextRecord()
+	_, _ = c.NextRecord()
+	_, err = c.FieldInt(1)
+	if !errors.As(err, &pe) || !errors.Is(err, ErrNoDigits) {
+		t.Fatalf("Expected ParseError with ErrNoDigits, got: %v", err)
+	}
+	if pe.Line != 2 || pe.Col != 3 || pe.Token != "x" {
+		t.Fatalf("ParseError = %+v; want line 2, col 3, token x", *pe)
+	}
+
+	if err := c.SetSeparator('"'); !errors.Is(err, ErrInvalidSeparator) {
+		t.Fatalf("Expected ErrInvalidSeparator, got: %v", err)
+	}
+}
+
+func TestCSVReaderBareQuoteKeepsLines(t *testing.T) {
+	const input = "a\"b,1\nc,2\nx,\"q\"\"\nr\"\nd,3\n"
+	readers := []*FastReader{
+		newTestReader(input),
+		NewReader(iotest.OneByteReader(strings.NewReader(input)), WithBufferSize(16)),
+	}
+	for _, r := range readers {
+		c := NewCSVReader(r)
+		_, err := c.NextRecord()
+		var pe *ParseError
+		if !errors.As(err, &pe) || !errors.Is(err, ErrBareQuote) || pe.Line != 1 {
+			t.Fatalf("NextRecord error = %v; want ErrBareQuote on line 1", err)
+		}
+		got := readAllCSV(t, c)
+		want := [][]string{{"c", "2"}, {"x", "q\"\nr"}, {"d", "3"}}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("records after bare quote = %q; want %q", got, want)
+		}
+	}
+}
+
+func TestCSVReaderLimits(t *testing.T) {
+	long := strings.Repeat("z", 1<<20)
+	input := "a,b\n" + long + ",1\n\"multi\n" + long + "\",2\nc,\"q\n\"\nshort," + long[:20] + "\nd,\"" + long
+	readers := map[string]*FastReader{
+		"plain":   NewReader(strings.NewReader(input), WithMaxLineSize(100), WithMaxTokenSize(10)),
+		"onebyte": NewReader(iotest.OneByteReader(strings.NewReader(input)), WithBufferSize(16), WithMaxLineSize(100), WithMaxTokenSize(10)),
+		"leave":   NewReader(strings.NewReader(input), WithBufferSize(64), WithMaxLineSize(100), WithMaxTokenSize(10), WithLongTokenMode(LongTokenLeave)),
+	}
+	for name, r := range readers {
+		c := NewCSVReader(r)
+		var got []string
+		var lines []int
+		for {
+			rec, err := c.NextRecord()
+			if err == io.EOF {
+				break
+			}
+			var pe *ParseError
+			switch {
+			case errors.As(err, &pe) && errors.Is(err, ErrTokenTooLong):
+				if len(pe.Token) > maxTokenPreview {
+					t.Errorf("%s: ParseError.Token has %d bytes", name, len(pe.Token))
+				}
+				got = append(got, "!")
+				lines = append(lines, pe.Line)
+			case err != nil:
+				t.Fatalf("%s: NextRecord error: %v", name, err)
+			default:
+				got = append(got, string(bytes.Join(rec, []byte("|"))))
+			}
+			if len(got) > 10 {
+				t.Fatalf("%s: reader does not reach EOF: %q", name, got)
+			}
+		}
+		want := []string{"a|b", "!", "!", "c|q\n", "!", "!"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got %q; want %q", name, got, want)
+		}
+		if wantLines := []int{2, 3, 7, 8}; !reflect.DeepEqual(lines, wantLines) {
+			t.Errorf("%s: error lines %v; want %v", name, lines, wantLines)
+		}
+		if cap(c.rec) > 1<<10 || len(r.buf) > 1<<16 {
+			t.Errorf("%s: record scratch grew to %d, buffer to %d", name, cap(c.rec), len(r.buf))
+		}
+	}
+}
+
+func TestCSVReaderNoAllocs(t *testing.T) {
+	data := strings.Repeat("12,\"quoted field\",3.5,plain\n", 2000)
+	c := NewCSVReader(newTestReader(data))
+	allocs := testing.AllocsPerRun(1000, func() {
+		if _, err := c.NextRecord(); err != nil {
+			t.Fatalf("NextRecord error: %v", err)
+		}
+		if _, err := c.FieldInt(0); err != nil {
+			t.Fatalf("FieldInt error: %v", err)
+		}
+	})
+	if allocs != 0 {
+		t.Fatalf("NextRecord allocs = %v; want 0", allocs)
+	}
+}
@@ -0,0 +1,197 @@
+package fastio
+
+import (
+	"bytes"
+	"strconv"
+)
+
+// CSVWriter записывает записи CSV/TSV (RFC 4180) поверх FastWriter.
+//
+// Поле заключается в кавычки, только если содержит разделитель,
+// кавычку, '\r' или '\n' либо начинается с пробела или табуляции;
+// кавычки внутри поля удваиваются.
+//
+// Запись строится вызовами Int, Float, String, Bytes и т. д.
+// и завершается EndRecord; WriteRecord записывает запись целиком.
+// Данные попадают прямо в буфер FastWriter, поэтому не забывайте
+// вызывать Flush().
+//
+// CSVWriter не является потокобезопасным.
+type CSVWriter struct {
+	fw   *FastWriter
+	sep  byte
+	crlf bool
+
+	// fields — число полей в текущей записи, lastEmpty — было ли
+	// последнее поле пустым (запись из одного пустого поля пишется как "").
+	fields    int
+	lastEmpty bool
+}
+
+// NewCSVWriter создаёт CSVWriter поверх fw с разделителем ','
+// и окончанием строк '\n'.
+func NewCSVWriter(fw *FastWriter) *CSVWriter {
+	return &CSVWriter{fw: fw, sep: ','}
+}
+
+// SetSeparator задаёт разделитель полей (например, ',' или '\t').
+// Кавычка, '\r' и '\n' не могут быть разделителем.
+func (c *CSVWriter) SetSeparator(sep byte) error {
+	if sep == '"' || sep == '\r' || sep == '\n' {
+		return ErrInvalidSeparator
+	}
+	c.sep = sep
+	return nil
+}
+
+// SetCRLF выбирает окончание записей: "\r\n" при true, "\n" при false.
+func (c *CSVWriter) SetCRLF(crlf bool) {
+	c.crlf = crlf
+}
+
+func (c *CSVWriter) beginField() error {
+	if c.fields > 0 {
+		if err := c.fw.WriteByte(c.sep); err != nil {
+			return err
+		}
+	}
+	c.fields++
+	c.lastEmpty = false
+	return nil
+}
+
+// String добавляет в текущую запись строковое поле.
+func (c *CSVWriter) String(s string) error {
+	return writeCSVField(c, s)
+}
+
+// Bytes добавляет в текущую запись поле из b.
+func (c *CSVWriter) Bytes(b []byte) error {
+	return writeCSVField(c, b)
+}
+
+// Int добавляет в текущую запись int в десятичном формате.
+func (c *CSVWriter) Int(v int) error {
+	return c.Int64(int64(v))
+}
+
+// Int64 добавляет в текущую запись int64 в десятичном формате.
+func (c *CSVWriter) Int64(v int64) error {
+	c.fw.scratch = strconv.AppendInt(c.fw.scratch[:0], v, 10)
+	return c.number()
+}
+
+// Uint64 добавляет в текущую запись uint64 в десятичном формате.
+func (c *CSVWriter) Uint64(v uint64) error {
+	c.fw.scratch = strconv.AppendUint(c.fw.scratch[:0], v, 10)
+	return c.number()
+}
+
+// Float добавляет в текущую запись float64 с prec знаками после точки,
+// как FastWriter.WriteFloat64; prec = -1 выбирает кратчайшую точную запись.
+func (c *CSVWriter) Float(v float64, prec int) error {
+	c.fw.scratch = strconv.AppendFloat(c.fw.scratch[:0], v, 'f', prec, 64)
+	return c.number()
+}
+
+// number записывает число из fw.scratch. Кавычки нужны,
+// только если разделитель совпадает с одним из символов числа.
+func (c *CSVWriter) number() error {
+	if bytes.IndexByte(c.fw.scratch, c.sep) >= 0 {
+		return writeCSVField(c, c.fw.scratch)
+	}
+	if err := c.beginField(); err != nil {
+		return err
+	}
+	return c.fw.WriteBytes(c.fw.scratch)
+}
+
+// EndRecord завершает текущую запись окончанием строки.
+func (c *CSVWriter) EndRecord() error {
+	if c.fields == 1 && c.lastEmpty {
+		// Иначе запись превратится в пустую строку, которую читатели пропускают.
+		if err := c.fw.WriteString(`""`); err != nil {
+			return err
+		}
+	}
+	c.fields = 0
+	c.lastEmpty = false
+	if c.crlf {
+		if err := c.fw.WriteByte('\r'); err != nil {
+			return err
+		}
+	}
+	return c.fw.WriteByte('\n')
+}
+
+// WriteRecord записывает запись из строковых полей и завершает её.
+func (c *CSVWriter) WriteRecord(fields []string) error {
+	for _, f := range fields {
+		if err := c.String(f); err != nil {
+			return err
+		}
+	}
+	return c.EndRecord()
+}
+
+// Flush сбрасывает буфер нижележащего FastWriter.
+func (c *CSVWriter) Flush() error {
+	return c.fw.Flush()
+}
+
+func writeCSVField[T ~[]byte | ~string](c *CSVWriter, f T) error {
+	if err := c.beginField(); err != nil {
+		return err
+	}
+	if len(f) == 0 {
+		c.lastEmpty = true
+		return nil
+	}
+	if !csvNeedsQuotes(f, c.sep) {
+		_, err := writeData(c.fw, f)
+		return err
+	}
+
+	if err := c.fw.WriteByte('"'); err != nil {
+		return err
+	}
+	for len(f) > 0 {
+		i := indexQuote(f)
+		if i < 0 {
+			if _, err := writeData(c.fw, f); err != nil {
+				return err
+			}
+			break
+		}
+		// Пишем кусок вместе с кавычкой и удваиваем её.
+		if _, err := writeData(c.fw, f[:i+1]); err != nil {
+			return err
+		}
+		if err := c.fw.WriteByte('"'); err != nil {
+			return err
+		}
+		f = f[i+1:]
+	}
+	return c.fw.WriteByte('"')
+}
+
+func csvNeedsQuotes[T ~[]byte | ~string](f T, sep byte) bool {
+	if f[0] == ' ' || f[0] == '\t' {
+		return true
+	}
+	for i := 0; i < len(f); i++ {
+		if b := f[i]; b == '"' || b == '\r' || b == '\n' || b == sep {
+			return true
+		}
+	}
+	return false
+}
+
+func indexQuote[T ~[]byte | ~string](f T) int {
+	for i := 0; i < len(f); i++ {
+		if f[i] == '"' {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,114 @@
+package fastio
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCSVWriterQuoting(t *testing.T) {
+	var buf bytes.Buffer
+	c := NewCSVWriter(NewWriter(&buf))
+
+	records := [][]string{
+		{"plain", "with,comma", `with "quotes"`, "multi\nline", " leading space", ""},
+		{""},
+		{"a", "b"},
+	}
+	for _, rec := range records {
+		if err := c.WriteRecord(rec); err != nil {
+			t.Fatalf("WriteRecord failed: %v", err)
+		}
+	}
+	if err := c.Flush(); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	want := "plain,\"with,comma\",\"with \"\"quotes\"\"\",\"multi\nline\",\" leading space\",\n\"\"\na,b\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("Output mismatch:\ngot  %q\nwant %q", got, want)
+	}
+
+	cr := csv.NewReader(strings.NewReader(buf.String()))
+	cr.FieldsPerRecord = -1
+	got, err := cr.ReadAll()
+	if err != nil {
+		t.Fatalf("encoding/csv error: %v", err)
+	}
+	if !reflect.DeepEqual(got, records) {
+		t.Fatalf("Round trip mismatch:\ngot  %q\nwant %q", got, records)
+	}
+}
+
+func TestCSVWriterTypedFieldsTSVAndCRLF(t *testing.T) {
+	var buf bytes.Buffer
+	c := NewCSVWriter(NewWriter(&buf))
+	if err := c.SetSeparator('\t'); err != nil {
+		t.Fatalf("SetSeparator failed: %v", err)
+	}
+	c.SetCRLF(true)
+
+	steps := []func() error{
+		func() error { return c.Int(-42) },
+		func() error { return c.Int64(9223372036854775807) },
+		func() error { return c.Uint64(18446744073709551615) },
+		func() error { return c.Float(3.14159, 2) },
+		func() error { return c.Bytes([]byte("tab\there")) },
+		func() error { return c.String("x") },
+		c.EndRecord,
+	}
+	for i, step := range steps {
+		if err := step(); err != nil {
+			t.Fatalf("step %d failed: %v", i, err)
+		}
+	}
+	if err := c.Flush(); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	want := "-42\t9223372036854775807\t18446744073709551615\t3.14\t\"tab\there\"\tx\r\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("Output mismatch:\ngot  %q\nwant %q", got, want)
+	}
+}
+
+func TestCSVWriterNumberWithSeparator(t *testing.T) {
+	var buf bytes.Buffer
+	c := NewCSVWriter(NewWriter(&buf))
+	if err := c.SetSeparator('.'); err != nil {
+		t.Fatalf("SetSeparator failed: %v", err)
+	}
+	_ = c.Float(1.5, 1)
+	_ = c.Int(2)
+	_ = c.EndRecord()
+	_ = c.Flush()
+
+	if got, want := buf.String(), "\"1.5\".2\n"; got != want {
+		t.Fatalf("Output mismatch: got %q, want %q", got, want)
+	}
+	if err := c.SetSeparator('\n'); !errors.Is(err, ErrInvalidSeparator) {
+		t.Fatalf("Expected ErrInvalidSeparator, got: %v", err)
+	}
+}
+
+func TestCSVWriterRoundTripWithCSVReader(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewCSVWriter(NewWriter(&buf, WithBufferSize(16)))
+	want := [][]string{{"id", "note"}, {"1", `he said "hi", then left`}, {"2", "line1\nline2"}}
+	for _, rec := range want {
+		if err := w.WriteRecord(rec); err != nil {
+			t.Fatalf("WriteRecord failed: %v", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	got := readAllCSV(t, NewCSVReader(NewReader(&buf)))
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Round trip mismatch:\ngot  %q\nwant %q", got, want)
+	}
+}
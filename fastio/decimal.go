@@ -0,0 +1,210 @@
+package fastio
+
+import (
+	"errors"
+	"math"
+	"strconv"
+)
+
+var (
+	// ErrPrecision возвращается NextDecimal, когда дробных цифр больше,
+	// чем допускает масштаб, а режим округления — RoundExact.
+	ErrPrecision = errors.New("too many fractional digits")
+	// ErrInvalidScale возвращается для масштаба вне 0..MaxDecimalScale.
+	ErrInvalidScale = errors.New("invalid decimal scale")
+)
+
+// MaxDecimalScale — наибольший масштаб Decimal: 10^18 ещё помещается в int64.
+const MaxDecimalScale = 18
+
+// Decimal — точное десятичное число с фиксированной точкой:
+// Value / 10^Scale. Например, 12345.67 при масштабе 2 хранится
+// как Decimal{Value: 1234567, Scale: 2}.
+type Decimal struct {
+	Value int64
+	Scale int
+}
+
+// String возвращает число ровно со Scale знаками после точки.
+func (d Decimal) String() string {
+	return string(appendDecimal(nil, d))
+}
+
+// RoundingMode задаёт, что делать с дробными цифрами сверх масштаба.
+type RoundingMode int
+
+const (
+	// RoundExact отвергает лишние ненулевые цифры с ErrPrecision.
+	RoundExact RoundingMode = iota
+	// RoundDown отбрасывает лишние цифры (округление к нулю).
+	RoundDown
+	// RoundHalfUp округляет половину от нуля: 0.125 -> 0.13, -0.125 -> -0.13.
+	RoundHalfUp
+	// RoundHalfEven округляет половину к чётному (банковское округление):
+	// 0.125 -> 0.12, 0.135 -> 0.14.
+	RoundHalfEven
+)
+
+// NextDecimal читает число вида [+-]digits[.digits] с масштабом scale
+// (0..MaxDecimalScale) без промежуточного float64. Недостающие дробные
+// цифры дополняются нулями: "12.5" при scale = 2 даёт {1250, 2}.
+// Лишние ненулевые дробные цифры отвергаются с ErrPrecision;
+// для округления используйте NextDecimalRound.
+//
+// Ошибки возвращаются как *ParseError с ErrSyntax, ErrRange,
+// ErrPrecision или ErrInvalidScale.
+func (fr *FastReader) NextDecimal(scale int) (Decimal, error) {
+	return fr.nextDecimal("NextDecimal", scale, RoundExact)
+}
+
+// NextDecimalRound работает как NextDecimal, но лишние дробные цифры
+// округляются в соответствии с mode.
+func (fr *FastReader) NextDecimalRound(scale int, mode RoundingMode) (Decimal, error) {
+	return fr.nextDecimal("NextDecimalRound", scale, mode)
+}
+
+func (fr *FastReader) nextDecimal(fn string, scale int, mode RoundingMode) (Decimal, error) {
+	if scale < 0 || scale > MaxDecimalScale {
+		return Decimal{}, fr.parseError(fn, fr.offset(), "", ErrInvalidScale)
+	}
+	if err := fr.SkipSpaces(); err != nil {
+		return Decimal{}, err
+	}
+	start := fr.offset()
+
+	token, err := fr.nextWordBytes(fn)
+	if err != nil {
+		return Decimal{}, err
+	}
+	d, err := parseDecimal(token, scale, mode)
+	if err != nil {
+		return Decimal{}, fr.parseError(fn, start, string(token), err)
+	}
+	return d, nil
+}
+
+// parseDecimal разбирает b целиком как десятичное число с масштабом scale.
+func parseDecimal(b []byte, scale int, mode RoundingMode) (Decimal, error) {
+	neg := false
+	limit := uint64(math.MaxInt64)
+	if len(b) > 0 && (b[0] == '+' || b[0] == '-') {
+		if b[0] == '-' {
+			neg = true
+			limit++
+		}
+		b = b[1:]
+	}
+
+	cutoff := limit / 10
+	var val uint64
+	overflow := false
+	add := func(c byte) {
+		if overflow || val > cutoff || val*10 > limit-uint64(c) {
+			overflow = true
+			return
+		}
+		val = val*10 + uint64(c)
+	}
+
+	digits, frac := 0, 0
+	dot := false
+	// first — первая лишняя дробная цифра (или -1), sticky — есть ли после неё ненулевые.
+	first, sticky := -1, false
+	for _, c := range b {
+		if c == '.' && !dot {
+			dot = true
+			continue
+		}
+		c -= '0'
+		if c > 9 {
+			return Decimal{}, ErrSyntax
+		}
+		digits++
+		switch {
+		case !dot:
+			add(c)
+		case frac < scale:
+			add(c)
+			frac++
+		case first < 0:
+			first = int(c)
+		case c != 0:
+			sticky = true
+		}
+	}
+	if digits == 0 {
+		return Decimal{}, ErrSyntax
+	}
+	for ; frac < scale; frac++ {
+		add(0)
+	}
+
+	if first >= 0 {
+		up := false
+		switch mode {
+		case RoundExact:
+			if first != 0 || sticky {
+				return Decimal{}, ErrPrecision
+			}
+		case RoundDown:
+		case RoundHalfUp:
+			up = first >= 5
+		case RoundHalfEven:
+			up = first > 5 || (first == 5 && (sticky || val%2 == 1))
+		}
+		if up && !overflow {
+			if val == limit {
+				overflow = true
+			} else {
+				val++
+			}
+		}
+	}
+	if overflow {
+		return Decimal{}, ErrRange
+	}
+	if neg {
+		return Decimal{Value: int64(-val), Scale: scale}, nil
+	}
+	return Decimal{Value: int64(val), Scale: scale}, nil
+}
+
+// WriteDecimal записывает d ровно со d.Scale знаками после точки,
+// например Decimal{Value: -5, Scale: 2} как "-0.05".
+// Для масштаба вне 0..MaxDecimalScale возвращает ErrInvalidScale.
+func (fw *FastWriter) WriteDecimal(d Decimal) error {
+	if d.Scale < 0 || d.Scale > MaxDecimalScale {
+		return ErrInvalidScale
+	}
+	fw.scratch = appendDecimal(fw.scratch[:0], d)
+	return fw.WriteBytes(fw.scratch)
+}
+
+// appendDecimal добавляет к dst запись d с d.Scale знаками после точки.
+func appendDecimal(dst []byte, d Decimal) []byte {
+	mag := uint64(d.Value)
+	if d.Value < 0 {
+		dst = append(dst, '-')
+		mag = -mag
+	}
+	start := len(dst)
+	dst = strconv.AppendUint(dst, mag, 10)
+	if d.Scale <= 0 {
+		return dst
+	}
+	// Дополняем нулями слева, чтобы перед точкой осталась хотя бы одна цифра.
+	if pad := d.Scale + 1 - (len(dst) - start); pad > 0 {
+		for i := 0; i < pad; i++ {
+			dst = append(dst, '0')
+		}
+		copy(dst[start+pad:], dst[start:len(dst)-pad])
+		for i := start; i < start+pad; i++ {
+			dst[i] = '0'
+		}
+	}
+	dst = append(dst, 0)
+	point := len(dst) - 1 - d.Scale
+	copy(dst[point+1:], dst[point:len(dst)-1])
+	dst[point] = '.'
+	return dst
+}
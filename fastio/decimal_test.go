@@ -0,0 +1,128 @@
+package fastio
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	cases := []struct {
+		in    string
+		scale int
+		mode  RoundingMode
+		want  Decimal
+		err   error
+	}{
+		{"12345.67", 2, RoundExact, Decimal{1234567, 2}, nil},
+		{"-12.5", 2, RoundExact, Decimal{-1250, 2}, nil},
+		{"+.5", 1, RoundExact, Decimal{5, 1}, nil},
+		{"7.", 0, RoundExact, Decimal{7, 0}, nil},
+		{"1.2300", 2, RoundExact, Decimal{123, 2}, nil},
+		{"1.231", 2, RoundExact, Decimal{}, ErrPrecision},
+		{"1.239", 2, RoundDown, Decimal{123, 2}, nil},
+		{"-1.239", 2, RoundDown, Decimal{-123, 2}, nil},
+		{"0.125", 2, RoundHalfUp, Decimal{13, 2}, nil},
+		{"-0.125", 2, RoundHalfUp, Decimal{-13, 2}, nil},
+		{"0.1249", 2, RoundHalfUp, Decimal{12, 2}, nil},
+		{"0.125", 2, RoundHalfEven, Decimal{12, 2}, nil},
+		{"0.135", 2, RoundHalfEven, Decimal{14, 2}, nil},
+		{"0.12501", 2, RoundHalfEven, Decimal{13, 2}, nil},
+		{"9223372036854775807", 0, RoundExact, Decimal{math.MaxInt64, 0}, nil},
+		{"-9223372036854775808", 0, RoundExact, Decimal{math.MinInt64, 0}, nil},
+		{"9223372036854775807.5", 0, RoundHalfUp, Decimal{}, ErrRange},
+		{"92233720368547758.08", 2, RoundExact, Decimal{}, ErrRange},
+		{"-92233720368547758.08", 2, RoundExact, Decimal{math.MinInt64, 2}, nil},
+		{".", 2, RoundExact, Decimal{}, ErrSyntax},
+		{"1.2.3", 2, RoundExact, Decimal{}, ErrSyntax},
+		{"1e5", 2, RoundExact, Decimal{}, ErrSyntax},
+		{"-", 2, RoundExact, Decimal{}, ErrSyntax},
+	}
+	for _, tc := range cases {
+		got, err := parseDecimal([]byte(tc.in), tc.scale, tc.mode)
+		if !errors.Is(err, tc.err) || got != tc.want {
+			t.Fatalf("parseDecimal(%q, %d, %d) = %+v, %v; want %+v, %v", tc.in, tc.scale, tc.mode, got, err, tc.want, tc.err)
+		}
+	}
+}
+
+func TestNextDecimal(t *testing.T) {
+	r := newTestReader("12345.67 0.005\n1.005 x")
+	d, err := r.NextDecimal(2)
+	if err != nil || d != (Decimal{1234567, 2}) {
+		t.Fatalf("NextDecimal = %+v, %v; want 12345.67", d, err)
+	}
+	_, err = r.NextDecimal(2)
+	var pe *ParseError
+	if !errors.As(err, &pe) || !errors.Is(err, ErrPrecision) {
+		t.Fatalf("Expected ParseError with ErrPrecision, got: %v", err)
+	}
+	if pe.Func != "NextDecimal" || pe.Token != "0.005" || pe.Col != 10 {
+		t.Fatalf("ParseError = %+v; want NextDecimal, token 0.005 at col 10", *pe)
+	}
+	d, err = r.NextDecimalRound(2, RoundHalfEven)
+	if err != nil || d.String() != "1.00" {
+		t.Fatalf("NextDecimalRound = %v, %v; want 1.00", d, err)
+	}
+	if _, err := r.NextDecimal(MaxDecimalScale + 1); !errors.Is(err, ErrInvalidScale) {
+		t.Fatalf("Expected ErrInvalidScale, got: %v", err)
+	}
+	if _, err := r.NextDecimal(2); !errors.Is(err, ErrSyntax) {
+		t.Fatalf("Expected ErrSyntax, got: %v", err)
+	}
+}
+
+func TestWriteDecimal(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	values := []Decimal{
+		{1234567, 2}, {-5, 2}, {0, 3}, {42, 0}, {-1, 0},
+		{math.MinInt64, 18}, {math.MaxInt64, 4},
+	}
+	for _, d := range values {
+		if err := w.WriteDecimal(d); err != nil {
+			t.Fatalf("WriteDecimal error: %v", err)
+		}
+		if err := w.WriteByte(' '); err != nil {
+			t.Fatalf("WriteByte error: %v", err)
+		}
+	}
+	if err := w.WriteDecimal(Decimal{1, -1}); !errors.Is(err, ErrInvalidScale) {
+		t.Fatalf("Expected ErrInvalidScale, got: %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush error: %v", err)
+	}
+	want := "12345.67 -0.05 0.000 42 -1 -9.223372036854775808 922337203685477.5807 "
+	if buf.String() != want {
+		t.Fatalf("output = %q; want %q", buf.String(), want)
+	}
+
+	// Запись читается обратно без потерь.
+	r := newTestReader(want)
+	for _, d := range values {
+		got, err := r.NextDecimal(d.Scale)
+		if err != nil || got != d {
+			t.Fatalf("NextDecimal round trip = %+v, %v; want %+v", got, err, d)
+		}
+	}
+}
+
+func TestNextDecimalNoAllocs(t *testing.T) {
+	r := newTestReader(string(bytes.Repeat([]byte("-12345.67 "), 2000)))
+	w := NewWriter(io.Discard)
+	allocs := testing.AllocsPerRun(1000, func() {
+		d, err := r.NextDecimal(2)
+		if err != nil {
+			t.Fatalf("NextDecimal error: %v", err)
+		}
+		if err := w.WriteDecimal(d); err != nil {
+			t.Fatalf("WriteDecimal error: %v", err)
+		}
+	})
+	if allocs != 0 {
+		t.Fatalf("NextDecimal/WriteDecimal allocs = %v; want 0", allocs)
+	}
+}
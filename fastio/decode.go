@@ -0,0 +1,398 @@
+package fastio
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+// ErrInvalidTag возвращается Decode для некорректного тега `fastio:"..."`.
+var ErrInvalidTag = errors.New("invalid fastio tag")
+
+// Decode читает запись из токенов, разделённых пробельными символами,
+// в структуру, на которую указывает v.
+//
+// Экспортируемые поля заполняются по порядку объявления, каждое —
+// одним токеном, как в Scan: числа всех размеров, string, []byte, bool
+// и big.Int, а также именованные типы на их основе. Вложенные структуры
+// читаются поле за полем, массивы — по числу элементов. Срез читается
+// как счётчик, за которым следуют элементы; с тегом len=Field длиной
+// служит значение ранее прочитанного целого поля Field. Память под
+// элементы среза выделяется по мере их чтения, так что большая длина
+// во входе без самих элементов не приводит к большой аллокации.
+//
+// Тег `fastio:"..."` содержит опции через запятую:
+//
+//   - "-" — поле пропускается;
+//   - order=N — поле читается N-м (с нуля); без опции позицией служит
+//     номер поля в объявлении;
+//   - len=Field — длина среза берётся из поля Field.
+//
+// Например:
+//
+//	type Order struct {
+//		ID    int
+//		Name  string
+//		N     int
+//		Items []int32 `fastio:"len=N"`
+//		Cache []byte  `fastio:"-"`
+//	}
+//
+// Разбор типа выполняется один раз и кэшируется: повторные вызовы
+// пишут значения прямо по смещениям полей, без рефлексии. Ошибки разбора
+// токенов возвращаются как *ParseError с Func = "Decode"; конец входа
+// посреди записи — как io.ErrUnexpectedEOF, до её начала — как io.EOF.
+func (fr *FastReader) Decode(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("fastio: Decode: %w %T", ErrUnsupportedType, v)
+	}
+	op, err := decoderFor(rv.Elem().Type())
+	if err != nil {
+		return err
+	}
+	start := fr.offset()
+	err = op(fr, rv.UnsafePointer())
+	if err == io.EOF && fr.offset() != start {
+		err = io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// decodeOp читает значение в память по адресу p.
+type decodeOp func(fr *FastReader, p unsafe.Pointer) error
+
+var (
+	decoders  sync.Map // reflect.Type -> decodeOp
+	decoderMu sync.Mutex
+)
+
+var bigIntType = reflect.TypeFor[big.Int]()
+
+func decoderFor(t reflect.Type) (decodeOp, error) {
+	if op, ok := decoders.Load(t); ok {
+		return op.(decodeOp), nil
+	}
+	decoderMu.Lock()
+	defer decoderMu.Unlock()
+	c := &decodeCompiler{pending: make(map[reflect.Type]*structDecoder)}
+	op, err := c.compile(t)
+	if err != nil {
+		return nil, err
+	}
+	for t, sd := range c.pending {
+		decoders.Store(t, decodeOp(sd.decode))
+	}
+	return op, nil
+}
+
+// decodeCompiler строит decodeOp для типа. pending хранит структуры,
+// разбор которых уже начат, чтобы рекурсивные типы ссылались сами на себя.
+type decodeCompiler struct {
+	pending map[reflect.Type]*structDecoder
+}
+
+func (c *decodeCompiler) compile(t reflect.Type) (decodeOp, error) {
+	if op, ok := decoders.Load(t); ok {
+		return op.(decodeOp), nil
+	}
+	if t == bigIntType {
+		return decodeBigInt, nil
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		if sd, ok := c.pending[t]; ok {
+			return sd.decode, nil
+		}
+		sd := &structDecoder{}
+		c.pending[t] = sd
+		if err := c.compileStruct(t, sd); err != nil {
+			return nil, err
+		}
+		return sd.decode, nil
+	case reflect.Array:
+		elem, err := c.compile(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		n, size := t.Len(), t.Elem().Size()
+		return func(fr *FastReader, p unsafe.Pointer) error {
+			for i := 0; i < n; i++ {
+				if err := elem(fr, unsafe.Add(p, uintptr(i)*size)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}, nil
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return decodeBytes, nil
+		}
+		elem, err := c.compile(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return func(fr *FastReader, p unsafe.Pointer) error {
+			n, _, err := fr.nextInteger("Decode", false, math.MaxInt)
+			if err != nil {
+				return err
+			}
+			return decodeSlice(fr, t, p, int(n), elem)
+		}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		bits := t.Bits()
+		max := uint64(1)<<(bits-1) - 1
+		return func(fr *FastReader, p unsafe.Pointer) error {
+			val, neg, err := fr.nextInteger("Decode", true, max)
+			if err != nil {
+				return err
+			}
+			if neg {
+				val = -val
+			}
+			storeUint(p, bits, val)
+			return nil
+		}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		bits := t.Bits()
+		max := uint64(math.MaxUint64) >> (64 - bits)
+		return func(fr *FastReader, p unsafe.Pointer) error {
+			val, _, err := fr.nextInteger("Decode", false, max)
+			if err != nil {
+				return err
+			}
+			storeUint(p, bits, val)
+			return nil
+		}, nil
+	case reflect.Float32:
+		return func(fr *FastReader, p unsafe.Pointer) error {
+			v, err := fr.scanFloat("Decode", 32)
+			*(*float32)(p) = float32(v)
+			return err
+		}, nil
+	case reflect.Float64:
+		return func(fr *FastReader, p unsafe.Pointer) error {
+			v, err := fr.scanFloat("Decode", 64)
+			*(*float64)(p) = v
+			return err
+		}, nil
+	case reflect.Bool:
+		return func(fr *FastReader, p unsafe.Pointer) error {
+			v, err := fr.scanBool("Decode")
+			*(*bool)(p) = v
+			return err
+		}, nil
+	case reflect.String:
+		return func(fr *FastReader, p unsafe.Pointer) error {
+			b, err := fr.word("Decode")
+			if err != nil {
+				return err
+			}
+			*(*string)(p) = string(b)
+			return nil
+		}, nil
+	}
+	return nil, fmt.Errorf("fastio: Decode: %w %s", ErrUnsupportedType, t)
+}
+
+// structDecoder читает поля структуры в порядке fields.
+type structDecoder struct {
+	fields []fieldDecoder
+}
+
+// fieldDecoder читает поле по смещению off. Для среза с тегом len=Field
+// op читает один элемент, а длина берётся из целого поля по смещению
+// lenOff размером lenBits (lenBits = 0 — тега нет).
+type fieldDecoder struct {
+	off     uintptr
+	op      decodeOp
+	typ     reflect.Type
+	lenOff  uintptr
+	lenBits int
+	lenSign bool
+}
+
+func (sd *structDecoder) decode(fr *FastReader, p unsafe.Pointer) error {
+	for i := range sd.fields {
+		f := &sd.fields[i]
+		fp := unsafe.Add(p, f.off)
+		if f.lenBits == 0 {
+			if err := f.op(fr, fp); err != nil {
+				return err
+			}
+			continue
+		}
+		n := loadUint(unsafe.Add(p, f.lenOff), f.lenBits)
+		if f.lenSign && n>>(f.lenBits-1)&1 != 0 || n > math.MaxInt {
+			return fr.parseError("Decode", fr.offset(), "", ErrRange)
+		}
+		if err := decodeSlice(fr, f.typ, fp, int(n), f.op); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *decodeCompiler) compileStruct(t reflect.Type, sd *structDecoder) error {
+	type field struct {
+		index  int
+		order  int
+		lenRef string
+	}
+	var fields []field
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		tag := sf.Tag.Get("fastio")
+		if tag == "-" {
+			continue
+		}
+		f := field{index: i, order: i}
+		if tag != "" {
+			for _, opt := range strings.Split(tag, ",") {
+				key, val, _ := strings.Cut(opt, "=")
+				switch key {
+				case "order":
+					n, err := strconv.Atoi(val)
+					if err != nil || n < 0 {
+						return tagError(t, sf, opt)
+					}
+					f.order = n
+				case "len":
+					if val == "" || sf.Type.Kind() != reflect.Slice || sf.Type.Elem().Kind() == reflect.Uint8 {
+						return tagError(t, sf, opt)
+					}
+					f.lenRef = val
+				default:
+					return tagError(t, sf, opt)
+				}
+			}
+		}
+		fields = append(fields, f)
+	}
+	sort.SliceStable(fields, func(i, j int) bool { return fields[i].order < fields[j].order })
+
+	decoded := make(map[string]bool, len(fields))
+	sd.fields = make([]fieldDecoder, 0, len(fields))
+	for _, f := range fields {
+		sf := t.Field(f.index)
+		fd := fieldDecoder{off: sf.Offset, typ: sf.Type}
+		var err error
+		if f.lenRef != "" {
+			lf, ok := t.FieldByName(f.lenRef)
+			if !ok || !decoded[f.lenRef] || len(lf.Index) != 1 || !isIntegerKind(lf.Type.Kind()) {
+				return tagError(t, sf, "len="+f.lenRef)
+			}
+			fd.lenOff = lf.Offset
+			fd.lenBits = lf.Type.Bits()
+			fd.lenSign = lf.Type.Kind() <= reflect.Int64
+			fd.op, err = c.compile(sf.Type.Elem())
+		} else {
+			fd.op, err = c.compile(sf.Type)
+		}
+		if err != nil {
+			return err
+		}
+		decoded[sf.Name] = true
+		sd.fields = append(sd.fields, fd)
+	}
+	return nil
+}
+
+func tagError(t reflect.Type, sf reflect.StructField, opt string) error {
+	return fmt.Errorf("fastio: Decode: %s.%s: %w %q", t, sf.Name, ErrInvalidTag, opt)
+}
+
+func isIntegerKind(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Uintptr
+}
+
+// storeUint записывает младшие bits бит v по адресу p.
+func storeUint(p unsafe.Pointer, bits int, v uint64) {
+	switch bits {
+	case 8:
+		*(*uint8)(p) = uint8(v)
+	case 16:
+		*(*uint16)(p) = uint16(v)
+	case 32:
+		*(*uint32)(p) = uint32(v)
+	default:
+		*(*uint64)(p) = v
+	}
+}
+
+// loadUint читает целое размером bits бит по адресу p.
+func loadUint(p unsafe.Pointer, bits int) uint64 {
+	switch bits {
+	case 8:
+		return uint64(*(*uint8)(p))
+	case 16:
+		return uint64(*(*uint16)(p))
+	case 32:
+		return uint64(*(*uint32)(p))
+	default:
+		return *(*uint64)(p)
+	}
+}
+
+// decodeSliceChunk — сколько элементов выделяется для среза сразу.
+// Длина среза приходит из входа, поэтому память под элементы растёт
+// по мере чтения, а не выделяется заранее под n.
+const decodeSliceChunk = 1024
+
+// growSlice готовит s к добавлению ещё одного из n ожидаемых элементов:
+// ёмкость растёт порциями, как в decodeSlice, а не сразу до n.
+func growSlice[S ~[]E, E any](s S, n int) S {
+	if len(s) == cap(s) {
+		s = slices.Grow(s, min(n-len(s), max(len(s), decodeSliceChunk)))
+	}
+	return s
+}
+
+// decodeSlice читает n элементов в срез типа t по адресу p,
+// переиспользуя его память.
+func decodeSlice(fr *FastReader, t reflect.Type, p unsafe.Pointer, n int, elem decodeOp) error {
+	v := reflect.NewAt(t, p).Elem()
+	v.SetLen(min(v.Cap(), n))
+	data, size := v.UnsafePointer(), t.Elem().Size()
+	for i := 0; i < n; i++ {
+		if i == v.Len() {
+			v.Grow(min(n-i, max(i, decodeSliceChunk)))
+			v.SetLen(min(v.Cap(), n))
+			data = v.UnsafePointer()
+		}
+		if err := elem(fr, unsafe.Add(data, uintptr(i)*size)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decodeBytes(fr *FastReader, p unsafe.Pointer) error {
+	b, err := fr.word("Decode")
+	if err != nil {
+		return err
+	}
+	dst := (*[]byte)(p)
+	*dst = append((*dst)[:0], b...)
+	return nil
+}
+
+func decodeBigInt(fr *FastReader, p unsafe.Pointer) error {
+	err := fr.NextBigInt((*big.Int)(p))
+	if pe, ok := err.(*ParseError); ok {
+		pe.Func = "Decode"
+	}
+	return err
+}
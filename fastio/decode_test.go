@@ -0,0 +1,158 @@
+package fastio
+
+import (
+	"errors"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type decodePoint struct {
+	X, Y int16
+}
+
+type decodeRecord struct {
+	ID     uint32
+	Name   string
+	Score  float64
+	Active bool
+	Pos    decodePoint
+	Tags   [2]string
+	N      int
+	Items  []int8 `fastio:"len=N"`
+	Extra  []decodePoint
+	Raw    []byte
+	Big    big.Int
+	Cache  string `fastio:"-"`
+	hidden int
+}
+
+func TestDecode(t *testing.T) {
+	r := newTestReader("7 ann 9.5 true 1 -2 a b 3 1 2 3 2 5 6 7 8 raw 123456789012345678901\n" +
+		"8 bob 1 0 0 0 c d 0 0 x 1")
+	var rec decodeRecord
+	rec.Cache = "keep"
+	if err := r.Decode(&rec); err != nil {
+		t.Fatalf("Decode error: %v", err)
+	}
+	if rec.ID != 7 || rec.Name != "ann" || rec.Score != 9.5 || !rec.Active || rec.Pos != (decodePoint{1, -2}) {
+		t.Fatalf("Decode = %+v", rec)
+	}
+	if rec.Tags != [2]string{"a", "b"} || rec.N != 3 || len(rec.Items) != 3 || rec.Items[2] != 3 {
+		t.Fatalf("Decode = %+v", rec)
+	}
+	if len(rec.Extra) != 2 || rec.Extra[1] != (decodePoint{7, 8}) || string(rec.Raw) != "raw" {
+		t.Fatalf("Decode = %+v", rec)
+	}
+	if rec.Big.String() != "123456789012345678901" || rec.Cache != "keep" || rec.hidden != 0 {
+		t.Fatalf("Decode = %+v", rec)
+	}
+
+	if err := r.Decode(&rec); err != nil {
+		t.Fatalf("second Decode error: %v", err)
+	}
+	if rec.ID != 8 || rec.Active || len(rec.Items) != 0 || len(rec.Extra) != 0 || string(rec.Raw) != "x" {
+		t.Fatalf("second Decode = %+v", rec)
+	}
+	if err := r.Decode(&rec); !errors.Is(err, io.EOF) {
+		t.Fatalf("Expected EOF error, got: %v", err)
+	}
+}
+
+func TestDecodeOrder(t *testing.T) {
+	var v struct {
+		A int `fastio:"order=2"`
+		B int `fastio:"order=0"`
+		C int `fastio:"order=1"`
+	}
+	if err := newTestReader("1 2 3").Decode(&v); err != nil || v.B != 1 || v.C != 2 || v.A != 3 {
+		t.Fatalf("Decode = %+v, %v; want B=1 C=2 A=3", v, err)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	var rec decodeRecord
+	err := newTestReader("7 ann 9.5 maybe").Decode(&rec)
+	var pe *ParseError
+	if !errors.As(err, &pe) || !errors.Is(err, ErrSyntax) || pe.Func != "Decode" || pe.Token != "maybe" {
+		t.Fatalf("Expected ParseError with ErrSyntax, got: %v", err)
+	}
+	if err := newTestReader("7 ann").Decode(&rec); err != io.ErrUnexpectedEOF {
+		t.Fatalf("Expected io.ErrUnexpectedEOF, got: %v", err)
+	}
+
+	var neg struct {
+		N     int
+		Items []int `fastio:"len=N"`
+	}
+	if err := newTestReader("-1").Decode(&neg); !errors.Is(err, ErrRange) {
+		t.Fatalf("negative len: expected ErrRange, got: %v", err)
+	}
+
+	// Длина из входа не выделяет память заранее: огромный счётчик
+	// упирается в конец входа, а не в панику при аллокации.
+	var huge struct{ Xs []int64 }
+	if err := newTestReader("100000000000000000 1 2").Decode(&huge); err != io.ErrUnexpectedEOF {
+		t.Fatalf("huge count: expected io.ErrUnexpectedEOF, got: %v", err)
+	}
+
+	var late struct {
+		Items []int `fastio:"len=N"`
+		N     int
+	}
+	if err := newTestReader("1").Decode(&late); !errors.Is(err, ErrInvalidTag) {
+		t.Fatalf("len before field: expected ErrInvalidTag, got: %v", err)
+	}
+	var bad struct {
+		A int `fastio:"size=1"`
+	}
+	if err := newTestReader("1").Decode(&bad); !errors.Is(err, ErrInvalidTag) {
+		t.Fatalf("unknown option: expected ErrInvalidTag, got: %v", err)
+	}
+	var m struct{ M map[string]int }
+	if err := newTestReader("1").Decode(&m); !errors.Is(err, ErrUnsupportedType) {
+		t.Fatalf("map field: expected ErrUnsupportedType, got: %v", err)
+	}
+	if err := newTestReader("1").Decode(rec); !errors.Is(err, ErrUnsupportedType) {
+		t.Fatalf("non-pointer: expected ErrUnsupportedType, got: %v", err)
+	}
+}
+
+func TestDecodeLongSlice(t *testing.T) {
+	const n = 3*decodeSliceChunk + 5
+	var sb strings.Builder
+	sb.WriteString(strconv.Itoa(n))
+	for i := 0; i < n; i++ {
+		sb.WriteString(" " + strconv.Itoa(i))
+	}
+	var rec struct{ Xs []int32 }
+	rec.Xs = make([]int32, 0, 10)
+	if err := newTestReader(sb.String()).Decode(&rec); err != nil {
+		t.Fatalf("Decode error: %v", err)
+	}
+	if len(rec.Xs) != n {
+		t.Fatalf("len = %d; want %d", len(rec.Xs), n)
+	}
+	for i, x := range rec.Xs {
+		if int(x) != i {
+			t.Fatalf("Xs[%d] = %d", i, x)
+		}
+	}
+}
+
+type decodeTree struct {
+	V        int
+	Children []decodeTree
+}
+
+func TestDecodeRecursive(t *testing.T) {
+	var tree decodeTree
+	if err := newTestReader("1 2 2 0 3 1 4 0").Decode(&tree); err != nil {
+		t.Fatalf("Decode error: %v", err)
+	}
+	if tree.V != 1 || len(tree.Children) != 2 || tree.Children[1].V != 3 || tree.Children[1].Children[0].V != 4 {
+		t.Fatalf("Decode = %+v", tree)
+	}
+}
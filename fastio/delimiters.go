@@ -0,0 +1,49 @@
+package fastio
+
+// SpaceDelimiters — разделители по умолчанию: пробел, '\t', '\r' и '\n'.
+const SpaceDelimiters = " \t\r\n"
+
+// Delimiters — таблица классов байтов: Delimiters[b] == true означает,
+// что байт b считается разделителем токенов.
+//
+// Таблица используется SkipSpaces и всеми методами Next*, читающими
+// токены (NextInt, NextFloat64, NextWord и т. д.). NextLine и NextLineBytes
+// по-прежнему разбивают вход только по '\n'.
+type Delimiters [256]bool
+
+var defaultDelimiters = *NewDelimiters(SpaceDelimiters)
+
+// NewDelimiters создаёт таблицу, в которой разделителями являются
+// ровно байты из chars. Для чтения значений через запятую вместе
+// с переводами строк: NewDelimiters(SpaceDelimiters + ",").
+func NewDelimiters(chars string) *Delimiters {
+	var d Delimiters
+	for i := 0; i < len(chars); i++ {
+		d[chars[i]] = true
+	}
+	return &d
+}
+
+// Contains сообщает, является ли b разделителем.
+func (d *Delimiters) Contains(b byte) bool {
+	return d[b]
+}
+
+// WithDelimiters задаёт таблицу разделителей FastReader.
+// Таблица копируется; nil означает SpaceDelimiters.
+// На FastWriter опция не влияет.
+func WithDelimiters(d *Delimiters) Option {
+	return func(o *options) {
+		o.delims = d
+	}
+}
+
+// SetDelimiters заменяет таблицу разделителей FastReader.
+// Таблица копируется, поэтому её можно менять после вызова без
+// влияния на ридер; nil восстанавливает SpaceDelimiters.
+func (fr *FastReader) SetDelimiters(d *Delimiters) {
+	if d == nil {
+		d = &defaultDelimiters
+	}
+	fr.delims = *d
+}
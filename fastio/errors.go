@@ -0,0 +1,48 @@
+package fastio
+
+import (
+	"errors"
+	"strconv"
+)
+
+var (
+	// ErrNoDigits возвращается, когда на месте числа не найдено ни одной цифры.
+	ErrNoDigits = errors.New("no digits found")
+	// ErrRange возвращается, когда число не помещается в целевой тип.
+	ErrRange = errors.New("value out of range")
+	// ErrSyntax возвращается, когда токен не является корректным числом.
+	ErrSyntax = errors.New("invalid syntax")
+)
+
+// maxTokenPreview — сколько байт токена попадает в ParseError.Token.
+const maxTokenPreview = 32
+
+// ParseError описывает ошибку разбора токена и место, где она произошла.
+//
+// Func — имя метода FastReader (например, "NextInt"),
+// Offset — абсолютное смещение начала токена в байтах,
+// Line и Col — строка и столбец начала токена (с единицы),
+// Token — текст токена (длинные токены усекаются до 32 байт),
+// Err — причина: ErrNoDigits, ErrRange или ErrSyntax.
+//
+// Проверяется через errors.Is(err, ErrRange) или errors.As(err, &pe).
+type ParseError struct {
+	Func   string
+	Offset int64
+	Line   int
+	Col    int
+	Token  string
+	Err    error
+}
+
+func (pe *ParseError) Error() string {
+	s := "fastio: " + pe.Func + ": " + strconv.Itoa(pe.Line) + ":" + strconv.Itoa(pe.Col) + ": "
+	if pe.Token != "" {
+		s += strconv.Quote(pe.Token) + ": "
+	}
+	return s + pe.Err.Error()
+}
+
+func (pe *ParseError) Unwrap() error {
+	return pe.Err
+}
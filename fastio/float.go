@@ -0,0 +1,441 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE-GO file.
+
+package fastio
+
+// Разбор чисел с плавающей точкой прямо из []byte без аллокаций.
+//
+// Алгоритм (точное умножение/деление для простых случаев и
+// «unrounded scaling» для остальных) адаптирован из
+// internal/strconv/uscale.go стандартной библиотеки Go,
+// см. https://research.swtch.com/fp. Результат совпадает
+// со strconv.ParseFloat бит в бит. Редкие случаи, которые быстрый путь
+// не решает однозначно (более 19 значащих цифр на границе округления),
+// а также шестнадцатеричные числа и числа с '_' передаются в strconv.ParseFloat.
+
+import (
+	"math"
+	"math/bits"
+	"strconv"
+)
+
+const (
+	float64MantBits = 52
+	float32MantBits = 23
+)
+
+var float64pow10 = [...]float64{
+	1e0, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9,
+	1e10, 1e11, 1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18, 1e19,
+	1e20, 1e21, 1e22,
+}
+
+var float32pow10 = [...]float32{1e0, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9, 1e10}
+
+// atof64 разбирает b как float64 по правилам strconv.ParseFloat(s, 64).
+// Возвращает ErrSyntax для некорректного токена и ErrRange
+// (вместе с ±Inf) при переполнении.
+func atof64(b []byte) (float64, error) {
+	if f, ok := special(b); ok {
+		return f, nil
+	}
+
+	d, p, neg, trunc, slow, ok := readFloat(b)
+	if slow {
+		return fallbackParseFloat(b, 64)
+	}
+	if !ok {
+		return 0, ErrSyntax
+	}
+
+	sign := uint64(0)
+	if neg {
+		sign = 1 << 63
+	}
+	if d == 0 {
+		return math.Float64frombits(sign), nil
+	}
+	if p > 310 {
+		return math.Float64frombits(sign | 0x7ff<<52), ErrRange
+	}
+	if p < -345 {
+		return math.Float64frombits(sign), nil
+	}
+	if !trunc {
+		if f, ok := atof64exact(d, p, neg); ok {
+			return f, nil
+		}
+	}
+	f, err := scaleFloat64(d, p, sign)
+	if !trunc {
+		return f, err
+	}
+	// Отброшенные цифры не влияют на результат, если d+1 даёт то же число.
+	if f1, _ := scaleFloat64(d+1, p, sign); f == f1 {
+		return f, err
+	}
+	return fallbackParseFloat(b, 64)
+}
+
+// atof32 разбирает b как float32 по правилам strconv.ParseFloat(s, 32).
+func atof32(b []byte) (float32, error) {
+	if f, ok := special(b); ok {
+		return float32(f), nil
+	}
+
+	d, p, neg, trunc, slow, ok := readFloat(b)
+	if slow {
+		f, err := fallbackParseFloat(b, 32)
+		return float32(f), err
+	}
+	if !ok {
+		return 0, ErrSyntax
+	}
+
+	sign := uint32(0)
+	if neg {
+		sign = 1 << 31
+	}
+	if d == 0 {
+		return math.Float32frombits(sign), nil
+	}
+	if p > 40 {
+		return math.Float32frombits(sign | 0xff<<23), ErrRange
+	}
+	if p < -70 {
+		return math.Float32frombits(sign), nil
+	}
+	if !trunc {
+		if f, ok := atof32exact(d, p, neg); ok {
+			return f, nil
+		}
+	}
+	f, err := scaleFloat32(d, p, sign)
+	if !trunc {
+		return f, err
+	}
+	if f1, _ := scaleFloat32(d+1, p, sign); f == f1 {
+		return f, err
+	}
+	f64, err := fallbackParseFloat(b, 32)
+	return float32(f64), err
+}
+
+// fallbackParseFloat — медленный путь через strconv.ParseFloat.
+func fallbackParseFloat(b []byte, bitSize int) (float64, error) {
+	f, err := strconv.ParseFloat(string(b), bitSize)
+	if err != nil {
+		if ne, ok := err.(*strconv.NumError); ok && ne.Err == strconv.ErrRange {
+			return f, ErrRange
+		}
+		return 0, ErrSyntax
+	}
+	return f, nil
+}
+
+// special распознаёт "inf", "infinity" (со знаком) и "nan"
+// без учёта регистра. Токен должен совпадать целиком.
+func special(b []byte) (float64, bool) {
+	if len(b) == 0 {
+		return 0, false
+	}
+	sign := 1
+	s := b
+	switch s[0] {
+	case '+', '-':
+		if s[0] == '-' {
+			sign = -1
+		}
+		s = s[1:]
+		if equalFoldASCII(s, "inf") || equalFoldASCII(s, "infinity") {
+			return math.Inf(sign), true
+		}
+	case 'i', 'I':
+		if equalFoldASCII(s, "inf") || equalFoldASCII(s, "infinity") {
+			return math.Inf(sign), true
+		}
+	case 'n', 'N':
+		if equalFoldASCII(s, "nan") {
+			return math.NaN(), true
+		}
+	}
+	return 0, false
+}
+
+// equalFoldASCII сравнивает b с word (в нижнем регистре) без учёта регистра.
+func equalFoldASCII(b []byte, word string) bool {
+	if len(b) != len(word) {
+		return false
+	}
+	for i := range b {
+		if b[i]|0x20 != word[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// readFloat разбирает десятичное число вида [+-]digits[.digits][(e|E)[+-]digits]
+// в мантиссу d (не более 19 значащих цифр) и десятичный порядок p.
+// trunc сообщает, что часть ненулевых цифр не поместилась в d.
+// slow сообщает о префиксе 0x или разделителях '_': такие токены
+// разбираются через strconv.
+func readFloat(s []byte) (d uint64, p int, neg, trunc, slow, ok bool) {
+	const maxMantDigits = 19
+
+	i := 0
+	if i < len(s) && (s[i] == '+' || s[i] == '-') {
+		neg = s[i] == '-'
+		i++
+	}
+	if i+1 < len(s) && s[i] == '0' && s[i+1]|0x20 == 'x' {
+		slow = true
+		return
+	}
+
+	sawdot := false
+	sawdigits := false
+	nd := 0
+	ndMant := 0
+	dp := 0
+loop:
+	for ; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '_':
+			slow = true
+			return
+		case c == '.':
+			if sawdot {
+				break loop
+			}
+			sawdot = true
+			dp = nd
+		case '0' <= c && c <= '9':
+			sawdigits = true
+			if c == '0' && nd == 0 { // ведущие нули
+				dp--
+				continue
+			}
+			nd++
+			if ndMant < maxMantDigits {
+				d = d*10 + uint64(c-'0')
+				ndMant++
+			} else if c != '0' {
+				trunc = true
+			}
+		default:
+			break loop
+		}
+	}
+	if !sawdigits {
+		return
+	}
+	if !sawdot {
+		dp = nd
+	}
+
+	if i < len(s) && s[i]|0x20 == 'e' {
+		i++
+		esign := 1
+		if i < len(s) && (s[i] == '+' || s[i] == '-') {
+			if s[i] == '-' {
+				esign = -1
+			}
+			i++
+		}
+		if i >= len(s) || s[i] < '0' || s[i] > '9' {
+			return
+		}
+		e := 0
+		for ; i < len(s) && ('0' <= s[i] && s[i] <= '9' || s[i] == '_'); i++ {
+			if s[i] == '_' {
+				slow = true
+				return
+			}
+			if e < 10000 {
+				e = e*10 + int(s[i]-'0')
+			}
+		}
+		dp += e * esign
+	}
+	if i != len(s) {
+		return
+	}
+
+	if d != 0 {
+		p = dp - ndMant
+	}
+	ok = true
+	return
+}
+
+// atof64exact вычисляет d*10^p точно в арифметике float64,
+// если d и 10^p представимы без потерь.
+func atof64exact(d uint64, p int, neg bool) (float64, bool) {
+	if d>>float64MantBits != 0 {
+		return 0, false
+	}
+	f := float64(d)
+	if neg {
+		f = -f
+	}
+	switch {
+	case p == 0:
+		return f, true
+	case p > 0 && p <= 15+22:
+		if p > 22 {
+			f *= float64pow10[p-22]
+			p = 22
+		}
+		if f > 1e15 || f < -1e15 {
+			return 0, false
+		}
+		return f * float64pow10[p], true
+	case p < 0 && p >= -22:
+		return f / float64pow10[-p], true
+	}
+	return 0, false
+}
+
+// atof32exact — аналог atof64exact для float32.
+func atof32exact(d uint64, p int, neg bool) (float32, bool) {
+	if d>>float32MantBits != 0 {
+		return 0, false
+	}
+	f := float32(d)
+	if neg {
+		f = -f
+	}
+	switch {
+	case p == 0:
+		return f, true
+	case p > 0 && p <= 7+10:
+		if p > 10 {
+			f *= float32pow10[p-10]
+			p = 10
+		}
+		if f > 1e7 || f < -1e7 {
+			return 0, false
+		}
+		return f * float32pow10[p], true
+	case p < 0 && p >= -10:
+		return f / float32pow10[-p], true
+	}
+	return 0, false
+}
+
+func bool2[T ~int | ~uint32 | ~uint64](b bool) T {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// unrounded хранит значение с двумя дополнительными битами:
+// «половина» и «липкий» бит для корректного округления.
+type unrounded uint64
+
+func (u unrounded) round() uint64 { return uint64((u + 1 + (u>>2)&1) >> 2) }
+
+// unmin возвращает минимальное unrounded, округляющееся до x.
+func unmin(x uint64) unrounded {
+	return unrounded(x<<2 - 2)
+}
+
+// log2Pow10 возвращает ⌊log₂ 10**x⌋.
+func log2Pow10(x int) int {
+	return (x * 108853) >> 15
+}
+
+// pmHiLo представляет hi<<64 - lo.
+type pmHiLo struct {
+	hi uint64
+	lo uint64
+}
+
+type scaler struct {
+	pmHi uint64
+	pmLo uint64
+	s    int
+}
+
+func prescale(pre *scaler, e, p, lp int) {
+	pre.pmHi = pow10Tab[p-pow10Min].hi
+	pre.pmLo = pow10Tab[p-pow10Min].lo
+	pre.s = -(e + lp + 3)
+}
+
+// uscale возвращает неокруглённое x * 2**e * 10**p.
+// x должен быть выровнен так, чтобы старший бит был установлен.
+func uscale(x uint64, c *scaler) unrounded {
+	hi, mid := bits.Mul64(x, c.pmHi)
+	s := c.s & 63
+	if hi>>s<<s != hi {
+		return unrounded(hi>>s | 1)
+	}
+	mid2, _ := bits.Mul64(x, c.pmLo)
+	hi -= bool2[uint64](mid < mid2)
+	return unrounded(hi>>s | bool2[uint64](mid-mid2 > 1))
+}
+
+// scaleFloat64 округляет d * 10**p до ближайшего float64.
+// d содержит не более 19 цифр. При переполнении возвращает ErrRange.
+func scaleFloat64(d uint64, p int, sign uint64) (float64, error) {
+	b := bits.Len64(d)
+	lp := log2Pow10(p)
+	e := min(1074, 53-b-lp)
+	var pre scaler
+	prescale(&pre, e-(64-b), p, lp)
+	if pre.s >= 64 {
+		return math.Float64frombits(sign), nil
+	}
+	u := uscale(d<<(64-b), &pre)
+
+	s := bool2[int](u >= unmin(1<<53))
+	u = u>>s | u&1
+	e = e - s
+
+	return pack64(sign|u.round(), -e)
+}
+
+// scaleFloat32 округляет d * 10**p до ближайшего float32.
+func scaleFloat32(d uint64, p int, sign uint32) (float32, error) {
+	b := bits.Len64(d)
+	lp := log2Pow10(p)
+	e := min(149, 24-b-lp)
+	var pre scaler
+	prescale(&pre, e-(64-b), p, lp)
+	if pre.s >= 64 {
+		return math.Float32frombits(sign), nil
+	}
+	u := uscale(d<<(64-b), &pre)
+
+	s := bool2[int](u >= unmin(1<<24))
+	u = u>>s | u&1
+	e = e - s
+
+	return pack32(sign|uint32(u.round()), -e)
+}
+
+// pack64 собирает float64 из 53-битной мантиссы m и порядка e.
+func pack64(m uint64, e int) (float64, error) {
+	if m&(1<<52) == 0 {
+		return math.Float64frombits(m), nil
+	}
+	if e >= 0x7FF-1075 {
+		return math.Float64frombits(m&(1<<63) | 0x7ff<<52), ErrRange
+	}
+	return math.Float64frombits(m&^(1<<52) | uint64(1075+e)<<52), nil
+}
+
+// pack32 собирает float32 из 24-битной мантиссы m и порядка e.
+func pack32(m uint32, e int) (float32, error) {
+	if m&(1<<23) == 0 {
+		return math.Float32frombits(m), nil
+	}
+	if e >= 0xFF-150 {
+		return math.Float32frombits(m&(1<<31) | 0xff<<23), ErrRange
+	}
+	return math.Float32frombits(m&^(1<<23) | uint32(150+e)<<23), nil
+}
@@ -0,0 +1,135 @@
+package fastio
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var floatSeeds = []string{
+	"0", "-0", "+0", "0.0", "1", "-1", "3.14", "-0.5", "1e3", "1E-3", ".5", "5.", "+.5e+2",
+	"123456789012345678", "1234567890123456789012345", "0.000000000000000000000000001",
+	"1.7976931348623157e308", "1.7976931348623159e308", "4.9e-324", "2.4e-324", "1e-400", "1e400",
+	"2.2250738585072011e-308", "2.2250738585072012e-308",
+	"9007199254740993", "9007199254740992.5", "179769313486231570000000000000000000000000e267",
+	"3.4028235e38", "3.4028236e38", "1.401298464324817e-45", "7e-46",
+	"inf", "-Inf", "+INFINITY", "nan", "NaN", "infinit", "+nan",
+	"0x1p-2", "-0x1.8p3", "0x1", "0x", "1_000", "1e", "1e+", ".", "-", "1.2.3", "1e5x", "e5", "",
+	"00000000000000000000000000000001", "1e-00000000000000000000000000005",
+}
+
+func sameFloat64(a, b float64) bool {
+	return math.Float64bits(a) == math.Float64bits(b) || (math.IsNaN(a) && math.IsNaN(b))
+}
+
+func checkAtof(t *testing.T, s string) {
+	t.Helper()
+
+	want, wantErr := strconv.ParseFloat(s, 64)
+	got, err := atof64([]byte(s))
+	switch {
+	case wantErr == nil:
+		if err != nil || !sameFloat64(got, want) {
+			t.Fatalf("atof64(%q) = %v, %v; want %v", s, got, err, want)
+		}
+	case errors.Is(wantErr, strconv.ErrRange):
+		if !errors.Is(err, ErrRange) || !sameFloat64(got, want) {
+			t.Fatalf("atof64(%q) = %v, %v; want %v, ErrRange", s, got, err, want)
+		}
+	default:
+		if !errors.Is(err, ErrSyntax) {
+			t.Fatalf("atof64(%q) error = %v; want ErrSyntax", s, err)
+		}
+	}
+
+	want32, wantErr := strconv.ParseFloat(s, 32)
+	got32, err := atof32([]byte(s))
+	switch {
+	case wantErr == nil:
+		if err != nil || !sameFloat64(float64(got32), want32) {
+			t.Fatalf("atof32(%q) = %v, %v; want %v", s, got32, err, want32)
+		}
+	case errors.Is(wantErr, strconv.ErrRange):
+		if !errors.Is(err, ErrRange) || !sameFloat64(float64(got32), want32) {
+			t.Fatalf("atof32(%q) = %v, %v; want %v, ErrRange", s, got32, err, want32)
+		}
+	default:
+		if !errors.Is(err, ErrSyntax) {
+			t.Fatalf("atof32(%q) error = %v; want ErrSyntax", s, err)
+		}
+	}
+}
+
+func TestAtofSeeds(t *testing.T) {
+	for _, s := range floatSeeds {
+		checkAtof(t, s)
+	}
+}
+
+func TestAtofRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 200000; i++ {
+		var s string
+		switch i % 4 {
+		case 0:
+			f := math.Float64frombits(rng.Uint64())
+			s = strconv.FormatFloat(f, 'g', -1, 64)
+		case 1:
+			f := math.Float64frombits(rng.Uint64())
+			s = strconv.FormatFloat(f, 'e', rng.Intn(25), 64)
+		case 2:
+			f := math.Float32frombits(rng.Uint32())
+			s = strconv.FormatFloat(float64(f), 'g', -1, 32)
+		default:
+			s = strconv.FormatInt(rng.Int63()>>uint(rng.Intn(63)), 10) + "." +
+				strconv.FormatInt(rng.Int63(), 10) + "e" + strconv.Itoa(rng.Intn(700)-350)
+		}
+		checkAtof(t, s)
+	}
+}
+
+func FuzzAtof(f *testing.F) {
+	for _, s := range floatSeeds {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		checkAtof(t, s)
+	})
+}
+
+func TestNextFloat32(t *testing.T) {
+	r := NewReader(strings.NewReader("0.1 -2.5e-3 inf 3.5e39"))
+	want := []float32{0.1, -2.5e-3, float32(math.Inf(1))}
+
+	for i, w := range want {
+		v, err := r.NextFloat32()
+		if err != nil {
+			t.Fatalf("NextFloat32 error at index %d: %v", i, err)
+		}
+		if v != w {
+			t.Fatalf("NextFloat32 at index %d = %v; want %v", i, v, w)
+		}
+	}
+
+	_, err := r.NextFloat32()
+	if !errors.Is(err, ErrRange) {
+		t.Fatalf("Expected ErrRange, got: %v", err)
+	}
+}
+
+func TestNextFloat64NoAllocs(t *testing.T) {
+	data := []byte(strings.Repeat("55.755826 -37.6173 1e-7 ", 1000))
+	r := NewReader(bytes.NewReader(data))
+	allocs := testing.AllocsPerRun(1000, func() {
+		if _, err := r.NextFloat64(); err != nil {
+			t.Fatalf("NextFloat64 error: %v", err)
+		}
+	})
+	if allocs != 0 {
+		t.Fatalf("NextFloat64 allocs = %v; want 0", allocs)
+	}
+}
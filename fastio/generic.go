@@ -0,0 +1,92 @@
+package fastio
+
+import (
+	"io"
+	"math"
+	"unsafe"
+)
+
+// Number — целые и вещественные типы, которые читают Next и NextSlice,
+// включая именованные типы на их основе (type ID int32).
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// numberKind описывает представление типа Number.
+type numberKind struct {
+	float  bool
+	signed bool
+	bits   int
+	max    uint64 // наибольшее значение для целых типов
+}
+
+func kindOf[T Number]() numberKind {
+	var k numberKind
+	one := T(1)
+	k.float = one/2 != 0
+	k.signed = one-2 < 0
+	k.bits = int(unsafe.Sizeof(one)) * 8
+	if !k.float {
+		if k.signed {
+			k.max = 1<<(k.bits-1) - 1
+		} else {
+			k.max = math.MaxUint64 >> (64 - k.bits)
+		}
+	}
+	return k
+}
+
+// Next читает одно число типа T. Целые читаются тем же ядром, что
+// и NextInt64, с проверкой диапазона T: "200" для int8 или "-1" для
+// uint16 дают *ParseError с ErrRange или ErrNoDigits. Вещественные
+// разбираются как NextFloat32 или NextFloat64 в зависимости от размера T.
+//
+//	v, err := fastio.Next[int8](fr)
+func Next[T Number](fr *FastReader) (T, error) {
+	return next[T](fr, "Next", kindOf[T]())
+}
+
+// NextSlice читает n чисел типа T и дописывает их в dst[:0], поэтому
+// срез, переданный повторно, переиспользуется без аллокаций:
+//
+//	n, _ := fr.NextInt()
+//	a, err = fastio.NextSlice(fr, n, a)
+//
+// Новая память выделяется по мере чтения порциями, как у Decode,
+// поэтому n из входа не резервирует её заранее.
+// При ошибке возвращаются уже прочитанные числа и сама ошибка;
+// конец входа до n-го числа возвращается как io.ErrUnexpectedEOF.
+func NextSlice[T Number](fr *FastReader, n int, dst []T) ([]T, error) {
+	k := kindOf[T]()
+	dst = dst[:0]
+	for i := 0; i < n; i++ {
+		v, err := next[T](fr, "NextSlice", k)
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return dst, err
+		}
+		dst = append(growSlice(dst, n), v)
+	}
+	return dst, nil
+}
+
+func next[T Number](fr *FastReader, fn string, k numberKind) (T, error) {
+	if k.float {
+		v, err := fr.scanFloat(fn, k.bits)
+		return T(v), err
+	}
+	val, neg, err := fr.nextInteger(fn, k.signed, k.max)
+	if err != nil {
+		return 0, err
+	}
+	if neg {
+		// Для math.MinInt64 отрицание переполняется обратно
+		// в MinInt64, что и требуется.
+		return T(-int64(val)), nil
+	}
+	return T(val), nil
+}
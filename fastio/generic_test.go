@@ -0,0 +1,95 @@
+package fastio
+
+import (
+	"errors"
+	"io"
+	"math"
+	"testing"
+)
+
+func TestNext(t *testing.T) {
+	r := newTestReader("-128 127 65535 -9223372036854775808 18446744073709551615 2.5 1e300 7")
+	if v, err := Next[int8](r); err != nil || v != math.MinInt8 {
+		t.Fatalf("Next[int8] = %d, %v; want -128", v, err)
+	}
+	if v, err := Next[int8](r); err != nil || v != math.MaxInt8 {
+		t.Fatalf("Next[int8] = %d, %v; want 127", v, err)
+	}
+	if v, err := Next[uint16](r); err != nil || v != math.MaxUint16 {
+		t.Fatalf("Next[uint16] = %d, %v; want 65535", v, err)
+	}
+	if v, err := Next[int64](r); err != nil || v != math.MinInt64 {
+		t.Fatalf("Next[int64] = %d, %v; want MinInt64", v, err)
+	}
+	if v, err := Next[uint](r); err != nil || v != math.MaxUint {
+		t.Fatalf("Next[uint] = %d, %v; want MaxUint", v, err)
+	}
+	if v, err := Next[float32](r); err != nil || v != 2.5 {
+		t.Fatalf("Next[float32] = %v, %v; want 2.5", v, err)
+	}
+	if v, err := Next[float64](r); err != nil || v != 1e300 {
+		t.Fatalf("Next[float64] = %v, %v; want 1e300", v, err)
+	}
+	type ID uint32
+	if v, err := Next[ID](r); err != nil || v != 7 {
+		t.Fatalf("Next[ID] = %d, %v; want 7", v, err)
+	}
+	if _, err := Next[int](r); !errors.Is(err, io.EOF) {
+		t.Fatalf("Expected EOF error, got: %v", err)
+	}
+}
+
+func TestNextRange(t *testing.T) {
+	var pe *ParseError
+	if _, err := Next[int8](newTestReader("-129")); !errors.As(err, &pe) || !errors.Is(err, ErrRange) || pe.Func != "Next" {
+		t.Fatalf("Next[int8](-129): expected ErrRange, got: %v", err)
+	}
+	if _, err := Next[uint16](newTestReader("65536")); !errors.Is(err, ErrRange) {
+		t.Fatalf("Next[uint16](65536): expected ErrRange, got: %v", err)
+	}
+	if _, err := Next[uint16](newTestReader("-1")); !errors.Is(err, ErrNoDigits) {
+		t.Fatalf("Next[uint16](-1): expected ErrNoDigits, got: %v", err)
+	}
+	if _, err := Next[float32](newTestReader("1e39")); !errors.Is(err, ErrRange) {
+		t.Fatalf("Next[float32](1e39): expected ErrRange, got: %v", err)
+	}
+}
+
+func TestNextSlice(t *testing.T) {
+	r := newTestReader("3 10 -20 30\n2 1 2\n3 5 300")
+	var a []int16
+	var first *int16
+	for _, want := range [][]int16{{10, -20, 30}, {1, 2}} {
+		n, _ := r.NextInt()
+		var err error
+		a, err = NextSlice(r, n, a)
+		if err != nil || len(a) != len(want) {
+			t.Fatalf("NextSlice = %v, %v; want %v", a, err, want)
+		}
+		for i := range want {
+			if a[i] != want[i] {
+				t.Fatalf("NextSlice = %v; want %v", a, want)
+			}
+		}
+		if first == nil {
+			first = &a[0]
+		}
+	}
+	// Срез переиспользуется.
+	if &a[0] != first {
+		t.Fatalf("NextSlice reallocated a slice with enough capacity")
+	}
+
+	n, _ := r.NextInt()
+	b, err := NextSlice[int8](r, n, nil)
+	var pe *ParseError
+	if !errors.As(err, &pe) || !errors.Is(err, ErrRange) || pe.Func != "NextSlice" || len(b) != 1 || b[0] != 5 {
+		t.Fatalf("NextSlice[int8] = %v, %v; want [5], ErrRange", b, err)
+	}
+	if b, err := NextSlice[int](newTestReader("1 2"), 1<<62, nil); err != io.ErrUnexpectedEOF || len(b) != 2 {
+		t.Fatalf("NextSlice(1<<62) = %v, %v; want [1 2], io.ErrUnexpectedEOF", b, err)
+	}
+	if _, err := NextSlice[int](newTestReader("1 2"), 3, nil); err != io.ErrUnexpectedEOF {
+		t.Fatalf("Expected io.ErrUnexpectedEOF, got: %v", err)
+	}
+}
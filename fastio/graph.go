@@ -0,0 +1,268 @@
+package fastio
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+// ErrNotTree возвращается ReadTree, если массив родителей содержит цикл
+// и не все вершины достижимы из корня 0.
+var ErrNotTree = errors.New("parent array is not a tree")
+
+// GraphOptions задаёт формат рёбер для ReadGraph и ReadTree.
+type GraphOptions struct {
+	// Weighted — после вершин ребра следует целый вес.
+	Weighted bool
+	// OneIndexed — вершины во входе нумеруются с единицы;
+	// в Graph они всегда нумеруются с нуля.
+	OneIndexed bool
+	// Directed — ребро u v добавляется только как u -> v;
+	// иначе в списки смежности попадают оба направления.
+	Directed bool
+}
+
+// Graph — граф в формате CSR (compressed sparse row): соседи вершины v
+// хранятся в Targets[Offsets[v]:Offsets[v+1]], их веса — в тех же
+// позициях Weights. Вершины нумеруются с нуля.
+type Graph struct {
+	N       int
+	Offsets []int   // len = N+1
+	Targets []int   // соседи всех вершин подряд
+	Weights []int64 // nil, если граф невзвешенный
+}
+
+// Neighbors возвращает соседей v в порядке следования рёбер во входе.
+// Срез указывает в Targets и не должен изменяться.
+func (g *Graph) Neighbors(v int) []int {
+	return g.Targets[g.Offsets[v]:g.Offsets[v+1]]
+}
+
+// EdgeWeights возвращает веса рёбер из v в том же порядке, что Neighbors.
+// Для невзвешенного графа возвращает nil.
+func (g *Graph) EdgeWeights(v int) []int64 {
+	if g.Weights == nil {
+		return nil
+	}
+	return g.Weights[g.Offsets[v]:g.Offsets[v+1]]
+}
+
+// Degree возвращает число соседей v.
+func (g *Graph) Degree(v int) int {
+	return g.Offsets[v+1] - g.Offsets[v]
+}
+
+// ReadGraph читает m рёбер графа с n вершинами: "u v" или "u v w"
+// с весом при opts.Weighted.
+//
+// Рёбра сначала читаются во временные массивы, которые растут по мере
+// чтения, как у Decode, затем за два прохода строится CSR: подсчёт
+// степеней и раскладка соседей. Память выделяется несколькими общими
+// срезами, без отдельного среза на каждую вершину.
+//
+// Число рёбер m может приходить из входа, а n — нет: вершины без рёбер
+// вход не подтверждает, и Offsets всегда занимает n+1 элементов.
+// Для недоверенного входа ограничьте n перед вызовом.
+//
+// Номер вершины вне диапазона возвращается как *ParseError с ErrRange,
+// конец входа до m-го ребра — как io.ErrUnexpectedEOF.
+func ReadGraph(fr *FastReader, n, m int, opts GraphOptions) (*Graph, error) {
+	if n < 0 || m < 0 {
+		return nil, fr.parseError("ReadGraph", fr.offset(), "", ErrInvalidShape)
+	}
+	var from, to []int
+	var w []int64
+	for i := 0; i < m; i++ {
+		u, err := readVertex(fr, "ReadGraph", n, opts.OneIndexed)
+		if err != nil {
+			return nil, err
+		}
+		v, err := readVertex(fr, "ReadGraph", n, opts.OneIndexed)
+		if err != nil {
+			return nil, err
+		}
+		from = append(growSlice(from, m), u)
+		to = append(growSlice(to, m), v)
+		if opts.Weighted {
+			x, err := readWeight(fr, "ReadGraph")
+			if err != nil {
+				return nil, err
+			}
+			w = append(growSlice(w, m), x)
+		}
+	}
+	if opts.Weighted && w == nil {
+		w = []int64{}
+	}
+	return buildCSR(n, from, to, w, opts.Directed), nil
+}
+
+// Tree — корневое дерево: CSR-граф и массив родителей,
+// Parent[0] = -1 для корня 0.
+type Tree struct {
+	*Graph
+	Parent []int
+}
+
+// ReadTree читает дерево из n вершин с корнем 0 в виде массива
+// родителей: n-1 чисел p_1 … p_{n-1}, где p_i — родитель вершины i
+// (при opts.OneIndexed — родители вершин 2 … n в нумерации с единицы).
+// С opts.Weighted за каждым родителем следует вес ребра.
+//
+// При opts.Directed в Graph попадают только рёбра родитель -> ребёнок,
+// иначе — оба направления. Массивы растут по мере чтения родителей,
+// поэтому n больше, чем есть во входе, даёт io.ErrUnexpectedEOF, а не
+// выделение памяти под n вершин. Массив родителей с циклом (например,
+// вершина — родитель самой себя) возвращается как ErrNotTree.
+// Остальные ошибки — как у ReadGraph.
+func ReadTree(fr *FastReader, n int, opts GraphOptions) (*Tree, error) {
+	if n < 0 {
+		return nil, fr.parseError("ReadTree", fr.offset(), "", ErrInvalidShape)
+	}
+	var parent []int
+	var w []int64
+	if n > 0 {
+		parent = []int{-1}
+	}
+	for v := 1; v < n; v++ {
+		p, err := readVertex(fr, "ReadTree", n, opts.OneIndexed)
+		if err != nil {
+			return nil, err
+		}
+		parent = append(growSlice(parent, n), p)
+		if opts.Weighted {
+			x, err := readWeight(fr, "ReadTree")
+			if err != nil {
+				return nil, err
+			}
+			w = append(growSlice(w, n-1), x)
+		}
+	}
+	if v := treeCycle(parent); v >= 0 {
+		if opts.OneIndexed {
+			v++
+		}
+		return nil, fmt.Errorf("fastio: ReadTree: %w: vertex %d is on a cycle", ErrNotTree, v)
+	}
+	if opts.Weighted && w == nil {
+		w = []int64{}
+	}
+	children := make([]int, max(n-1, 0))
+	for i := range children {
+		children[i] = i + 1
+	}
+	return &Tree{Graph: buildCSR(n, parent[min(1, n):], children, w, opts.Directed), Parent: parent}, nil
+}
+
+// treeCycle возвращает вершину, из которой по parent нельзя дойти
+// до корня 0, или -1, если parent задаёт дерево. Каждая вершина
+// проходится не более двух раз.
+func treeCycle(parent []int) int {
+	// state[v]: 0 — не проверена, -1 — ведёт к корню,
+	// u > 0 — на текущем пути, начатом из вершины u.
+	state := make([]int, len(parent))
+	if len(parent) > 0 {
+		state[0] = -1
+	}
+	for v := 1; v < len(parent); v++ {
+		u := v
+		for state[u] == 0 {
+			state[u] = v
+			u = parent[u]
+		}
+		if state[u] == v {
+			return u
+		}
+		for u := v; state[u] == v; u = parent[u] {
+			state[u] = -1
+		}
+	}
+	return -1
+}
+
+// readVertex читает номер вершины и приводит его к нумерации с нуля.
+func readVertex(fr *FastReader, fn string, n int, oneIndexed bool) (int, error) {
+	if err := fr.SkipSpaces(); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, err
+	}
+	start := fr.offset()
+	val, neg, err := fr.nextInteger(fn, true, math.MaxInt)
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, err
+	}
+	v := int(val)
+	if neg {
+		v = -v
+	}
+	raw := v
+	if oneIndexed {
+		v--
+	}
+	if v < 0 || v >= n {
+		return 0, fr.parseError(fn, start, strconv.Itoa(raw), ErrRange)
+	}
+	return v, nil
+}
+
+func readWeight(fr *FastReader, fn string) (int64, error) {
+	val, neg, err := fr.nextInteger(fn, true, math.MaxInt64)
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, err
+	}
+	if neg {
+		return int64(-val), nil
+	}
+	return int64(val), nil
+}
+
+// buildCSR раскладывает рёбра from[i] -> to[i] по вершинам, сохраняя
+// порядок входа. Для неориентированного графа добавляется и to[i] -> from[i].
+func buildCSR(n int, from, to []int, w []int64, directed bool) *Graph {
+	e := len(from)
+	if !directed {
+		e *= 2
+	}
+	g := &Graph{N: n, Offsets: make([]int, n+1), Targets: make([]int, e)}
+	if w != nil {
+		g.Weights = make([]int64, e)
+	}
+
+	for i := range from {
+		g.Offsets[from[i]+1]++
+		if !directed {
+			g.Offsets[to[i]+1]++
+		}
+	}
+	for v := 0; v < n; v++ {
+		g.Offsets[v+1] += g.Offsets[v]
+	}
+
+	// pos — следующая свободная позиция в списке каждой вершины.
+	pos := make([]int, n)
+	copy(pos, g.Offsets[:n])
+	add := func(u, v, i int) {
+		g.Targets[pos[u]] = v
+		if w != nil {
+			g.Weights[pos[u]] = w[i]
+		}
+		pos[u]++
+	}
+	for i := range from {
+		add(from[i], to[i], i)
+		if !directed {
+			add(to[i], from[i], i)
+		}
+	}
+	return g
+}
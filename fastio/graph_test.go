@@ -0,0 +1,117 @@
+package fastio
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestReadGraph(t *testing.T) {
+	r := newTestReader("4 4\n1 2 5\n1 3 -1\n3 4 7\n2 1 2\n")
+	n, _ := r.NextInt()
+	m, _ := r.NextInt()
+	g, err := ReadGraph(r, n, m, GraphOptions{Weighted: true, OneIndexed: true})
+	if err != nil {
+		t.Fatalf("ReadGraph error: %v", err)
+	}
+	wantAdj := [][]int{{1, 2, 1}, {0, 0}, {0, 3}, {2}}
+	wantW := [][]int64{{5, -1, 2}, {5, 2}, {-1, 7}, {7}}
+	for v := 0; v < n; v++ {
+		if !reflect.DeepEqual(g.Neighbors(v), wantAdj[v]) || !reflect.DeepEqual(g.EdgeWeights(v), wantW[v]) {
+			t.Fatalf("vertex %d: Neighbors = %v, EdgeWeights = %v; want %v, %v",
+				v, g.Neighbors(v), g.EdgeWeights(v), wantAdj[v], wantW[v])
+		}
+	}
+	if g.Degree(0) != 3 || len(g.Targets) != 2*m {
+		t.Fatalf("Degree(0) = %d, len(Targets) = %d", g.Degree(0), len(g.Targets))
+	}
+
+	g, err = ReadGraph(newTestReader("0 1 1 2 0 2"), 3, 3, GraphOptions{Directed: true})
+	if err != nil {
+		t.Fatalf("ReadGraph directed error: %v", err)
+	}
+	if !reflect.DeepEqual(g.Offsets, []int{0, 2, 3, 3}) || !reflect.DeepEqual(g.Targets, []int{1, 2, 2}) || g.Weights != nil {
+		t.Fatalf("ReadGraph directed = %+v", g)
+	}
+}
+
+func TestReadGraphErrors(t *testing.T) {
+	_, err := ReadGraph(newTestReader("1 2\n2 4"), 3, 2, GraphOptions{OneIndexed: true})
+	var pe *ParseError
+	if !errors.As(err, &pe) || !errors.Is(err, ErrRange) || pe.Token != "4" || pe.Line != 2 {
+		t.Fatalf("Expected ParseError with ErrRange for vertex 4, got: %v", err)
+	}
+	if _, err := ReadGraph(newTestReader("0 1"), 3, 1, GraphOptions{OneIndexed: true}); !errors.Is(err, ErrRange) {
+		t.Fatalf("Expected ErrRange for vertex 0 in 1-indexed input, got: %v", err)
+	}
+	if _, err := ReadGraph(newTestReader("0 1 1"), 3, 2, GraphOptions{}); err != io.ErrUnexpectedEOF {
+		t.Fatalf("Expected io.ErrUnexpectedEOF, got: %v", err)
+	}
+}
+
+func TestReadTree(t *testing.T) {
+	// Родители вершин 2..5: 1 1 2 2.
+	tr, err := ReadTree(newTestReader("1 1 2 2"), 5, GraphOptions{OneIndexed: true, Directed: true})
+	if err != nil {
+		t.Fatalf("ReadTree error: %v", err)
+	}
+	if !reflect.DeepEqual(tr.Parent, []int{-1, 0, 0, 1, 1}) {
+		t.Fatalf("Parent = %v", tr.Parent)
+	}
+	if !reflect.DeepEqual(tr.Neighbors(0), []int{1, 2}) || !reflect.DeepEqual(tr.Neighbors(1), []int{3, 4}) || tr.Degree(3) != 0 {
+		t.Fatalf("children = %v %v", tr.Neighbors(0), tr.Neighbors(1))
+	}
+
+	tr, err = ReadTree(newTestReader("0 10 1 20"), 3, GraphOptions{Weighted: true})
+	if err != nil {
+		t.Fatalf("ReadTree weighted error: %v", err)
+	}
+	if !reflect.DeepEqual(tr.Neighbors(1), []int{0, 2}) || !reflect.DeepEqual(tr.EdgeWeights(1), []int64{10, 20}) {
+		t.Fatalf("Neighbors(1) = %v, EdgeWeights(1) = %v", tr.Neighbors(1), tr.EdgeWeights(1))
+	}
+
+	if tr, err := ReadTree(newTestReader(""), 1, GraphOptions{}); err != nil || tr.N != 1 || tr.Parent[0] != -1 {
+		t.Fatalf("ReadTree single vertex = %+v, %v", tr, err)
+	}
+}
+
+func TestReadGraphHugeSizes(t *testing.T) {
+	// Размеры из заголовка не должны резервировать память до чтения рёбер.
+	if _, err := ReadGraph(newTestReader("0 1 2"), 3, 1<<60, GraphOptions{Weighted: true}); err != io.ErrUnexpectedEOF {
+		t.Fatalf("ReadGraph(m = 1<<60) error = %v; want io.ErrUnexpectedEOF", err)
+	}
+	if _, err := ReadTree(newTestReader("0 0 1"), 1<<60, GraphOptions{}); err != io.ErrUnexpectedEOF {
+		t.Fatalf("ReadTree(n = 1<<60) error = %v; want io.ErrUnexpectedEOF", err)
+	}
+}
+
+func TestReadTreeNotTree(t *testing.T) {
+	tests := []struct {
+		in   string
+		n    int
+		opts GraphOptions
+	}{
+		{"0 3 2", 4, GraphOptions{}},                   // 2 -> 3 -> 2
+		{"0 2", 3, GraphOptions{}},                     // 2 — родитель самой себя
+		{"1 4 5 3", 5, GraphOptions{OneIndexed: true}}, // 3 -> 4 -> 5 -> 3
+	}
+	for _, tt := range tests {
+		_, err := ReadTree(newTestReader(tt.in), tt.n, tt.opts)
+		if !errors.Is(err, ErrNotTree) {
+			t.Errorf("ReadTree(%q, %d) error = %v; want ErrNotTree", tt.in, tt.n, err)
+		}
+	}
+	// Глубокая цепочка проверяется без рекурсии.
+	in := make([]byte, 0, 8<<20)
+	n := 1 << 20
+	for v := 1; v < n; v++ {
+		in = strconv.AppendInt(in, int64(v-1), 10)
+		in = append(in, ' ')
+	}
+	tr, err := ReadTree(newTestReader(string(in)), n, GraphOptions{Directed: true})
+	if err != nil || tr.Parent[n-1] != n-2 {
+		t.Fatalf("ReadTree chain error = %v", err)
+	}
+}
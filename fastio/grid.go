@@ -0,0 +1,149 @@
+package fastio
+
+import (
+	"errors"
+	"io"
+	"math"
+	"strconv"
+)
+
+var (
+	// ErrRowWidth возвращается, когда длина строки сетки или матрицы
+	// не совпадает с заданным числом столбцов.
+	ErrRowWidth = errors.New("row width mismatch")
+	// ErrInvalidShape возвращается для отрицательных или слишком больших
+	// размеров сетки или матрицы.
+	ErrInvalidShape = errors.New("invalid grid shape")
+)
+
+// gridChunk — сколько байт строк NextGrid выделяется заранее, пока
+// прочитано мало строк.
+const gridChunk = 64 << 10
+
+// NextGrid читает rows строк по cols символов, например карту из "#" и ".".
+// Каждая строка — один токен без разделителей; строка другой длины
+// возвращается как *ParseError с ErrRowWidth. Символы — байты,
+// WithInvalidUTF8 к ним не применяется.
+//
+// Строки результата нарезаются из общих срезов, которые выделяются
+// по мере чтения и растут вдвое, поэтому число аллокаций логарифмически
+// зависит от rows, а размеры из заголовка входа не резервируют память
+// заранее. Конец входа до rows-й строки возвращается как io.ErrUnexpectedEOF.
+func (fr *FastReader) NextGrid(rows, cols int) ([][]byte, error) {
+	if rows < 0 || cols < 0 || cols > 0 && rows > math.MaxInt/cols {
+		return nil, fr.parseError("NextGrid", fr.offset(), "", ErrInvalidShape)
+	}
+	var grid [][]byte
+	var data []byte
+	for i := 0; i < rows; i++ {
+		if err := fr.SkipSpaces(); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+		start := fr.offset()
+		row, err := fr.nextWordBytes("NextGrid")
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+		if len(row) != cols {
+			return nil, fr.parseError("NextGrid", start, string(row), ErrRowWidth)
+		}
+		if len(data) < cols {
+			data = make([]byte, min(rows-i, max(i, gridChunk/max(cols, 1), 1))*cols)
+		}
+		grid = growSlice(grid, rows)
+		grid = append(grid, data[:cols:cols])
+		copy(data, row)
+		data = data[cols:]
+	}
+	return grid, nil
+}
+
+// NextMatrixInt64 читает rows*cols целых чисел и возвращает их
+// одним срезом по строкам: элемент (i, j) находится в m[i*cols+j].
+// Срез растёт по мере чтения, как у Decode. Ошибки разбора — как
+// у NextInt64; конец входа до последнего числа возвращается
+// как io.ErrUnexpectedEOF.
+func (fr *FastReader) NextMatrixInt64(rows, cols int) ([]int64, error) {
+	if rows < 0 || cols < 0 || cols > 0 && rows > math.MaxInt/cols {
+		return nil, fr.parseError("NextMatrixInt64", fr.offset(), "", ErrInvalidShape)
+	}
+	n := rows * cols
+	var m []int64
+	for i := 0; i < n; i++ {
+		val, neg, err := fr.nextInteger("NextMatrixInt64", true, math.MaxInt64)
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+		m = growSlice(m, n)
+		if neg {
+			m = append(m, int64(-val))
+		} else {
+			m = append(m, int64(val))
+		}
+	}
+	return m, nil
+}
+
+// WriteGrid записывает строки grid, завершая каждую '\n'.
+// Если sep не пуст, он вставляется между символами строки:
+// WriteGrid(g, " ") пишет "# . #" вместо "#.#".
+func (fw *FastWriter) WriteGrid(grid [][]byte, sep string) error {
+	for _, row := range grid {
+		if sep == "" {
+			if err := fw.WriteBytes(row); err != nil {
+				return err
+			}
+		} else {
+			for j, c := range row {
+				if j > 0 {
+					if err := fw.WriteString(sep); err != nil {
+						return err
+					}
+				}
+				if err := fw.WriteByte(c); err != nil {
+					return err
+				}
+			}
+		}
+		if err := fw.WriteByte('\n'); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteMatrix записывает матрицу m, хранящуюся по строкам, как это
+// возвращает NextMatrixInt64: по cols чисел в строке через sep,
+// каждая строка завершается '\n'. Если len(m) не делится на cols,
+// ничего не пишется и возвращается ErrRowWidth.
+func (fw *FastWriter) WriteMatrix(m []int64, cols int, sep string) error {
+	if cols <= 0 && len(m) > 0 || cols > 0 && len(m)%cols != 0 {
+		return ErrRowWidth
+	}
+	for i, v := range m {
+		if i%cols != 0 {
+			if err := fw.WriteString(sep); err != nil {
+				return err
+			}
+		}
+		fw.scratch = strconv.AppendInt(fw.scratch[:0], v, 10)
+		if err := fw.WriteBytes(fw.scratch); err != nil {
+			return err
+		}
+		if (i+1)%cols == 0 {
+			if err := fw.WriteByte('\n'); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package fastio
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestNextGrid(t *testing.T) {
+	in := "3 4\n#..#\r\n.##.\n\n#..#\n"
+	for _, r := range []*FastReader{
+		newTestReader(in),
+		NewReader(iotest.OneByteReader(strings.NewReader(in)), WithBufferSize(16)),
+	} {
+		rows, _ := r.NextInt()
+		cols, _ := r.NextInt()
+		g, err := r.NextGrid(rows, cols)
+		if err != nil {
+			t.Fatalf("NextGrid error: %v", err)
+		}
+		if string(bytes.Join(g, []byte("|"))) != "#..#|.##.|#..#" {
+			t.Fatalf("NextGrid = %q", g)
+		}
+		// Строки лежат в одном срезе, но append к строке не затирает следующую.
+		if cap(g[0]) != cols {
+			t.Fatalf("cap(row) = %d; want %d", cap(g[0]), cols)
+		}
+		g[0] = append(g[0], 'x')
+		if g[1][0] != '.' {
+			t.Fatalf("append to row 0 overwrote row 1")
+		}
+	}
+}
+
+func TestNextGridErrors(t *testing.T) {
+	_, err := newTestReader("abc\nab\n").NextGrid(2, 3)
+	var pe *ParseError
+	if !errors.As(err, &pe) || !errors.Is(err, ErrRowWidth) || pe.Line != 2 || pe.Token != "ab" {
+		t.Fatalf("Expected ParseError with ErrRowWidth at line 2, got: %v", err)
+	}
+	if _, err := newTestReader("abc").NextGrid(2, 3); err != io.ErrUnexpectedEOF {
+		t.Fatalf("Expected io.ErrUnexpectedEOF, got: %v", err)
+	}
+	if _, err := newTestReader("").NextGrid(-1, 3); !errors.Is(err, ErrInvalidShape) {
+		t.Fatalf("Expected ErrInvalidShape, got: %v", err)
+	}
+	if g, err := newTestReader("").NextGrid(0, 3); err != nil || len(g) != 0 {
+		t.Fatalf("NextGrid(0, 3) = %q, %v; want empty", g, err)
+	}
+}
+
+func TestNextGridHugeShape(t *testing.T) {
+	// Размеры из заголовка не должны резервировать память до чтения строк.
+	if _, err := newTestReader("ab\ncd\n").NextGrid(1<<40, 2); err != io.ErrUnexpectedEOF {
+		t.Fatalf("NextGrid(1<<40, 2) error = %v; want io.ErrUnexpectedEOF", err)
+	}
+	if _, err := newTestReader("ab\n").NextGrid(1<<40, 1<<20); !errors.Is(err, ErrRowWidth) {
+		t.Fatalf("NextGrid(1<<40, 1<<20) error = %v; want ErrRowWidth", err)
+	}
+	if _, err := newTestReader("1 2 3").NextMatrixInt64(1<<31, 1<<31); err != io.ErrUnexpectedEOF {
+		t.Fatalf("NextMatrixInt64(1<<31, 1<<31) error = %v; want io.ErrUnexpectedEOF", err)
+	}
+	if _, err := newTestReader("1 2 3").NextMatrixInt64(1<<32, 1<<32); !errors.Is(err, ErrInvalidShape) {
+		t.Fatalf("NextMatrixInt64(1<<32, 1<<32) error = %v; want ErrInvalidShape", err)
+	}
+}
+
+func TestNextGridManyRows(t *testing.T) {
+	in := strings.Repeat("ab\ncd\n", 5000)
+	g, err := newTestReader(in).NextGrid(10000, 2)
+	if err != nil {
+		t.Fatalf("NextGrid error: %v", err)
+	}
+	for i, row := range g {
+		want := "ab"
+		if i%2 == 1 {
+			want = "cd"
+		}
+		if string(row) != want {
+			t.Fatalf("row %d = %q; want %q", i, row, want)
+		}
+	}
+}
+
+func TestNextMatrixInt64(t *testing.T) {
+	m, err := newTestReader("1 -2 3\n4 5 -9223372036854775808\n").NextMatrixInt64(2, 3)
+	if err != nil {
+		t.Fatalf("NextMatrixInt64 error: %v", err)
+	}
+	want := []int64{1, -2, 3, 4, 5, -9223372036854775808}
+	for i := range want {
+		if m[i] != want[i] {
+			t.Fatalf("NextMatrixInt64 = %v; want %v", m, want)
+		}
+	}
+	var pe *ParseError
+	if _, err := newTestReader("1 x").NextMatrixInt64(1, 2); !errors.As(err, &pe) || pe.Func != "NextMatrixInt64" {
+		t.Fatalf("Expected ParseError from NextMatrixInt64, got: %v", err)
+	}
+	if _, err := newTestReader("1 2 3").NextMatrixInt64(2, 2); err != io.ErrUnexpectedEOF {
+		t.Fatalf("Expected io.ErrUnexpectedEOF, got: %v", err)
+	}
+}
+
+func TestWriteGridAndMatrix(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	g := [][]byte{[]byte("#.#"), []byte("..#")}
+	_ = w.WriteGrid(g, "")
+	_ = w.WriteGrid(g, " ")
+	_ = w.WriteMatrix([]int64{1, -2, 3, 4}, 2, "\t")
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush error: %v", err)
+	}
+	want := "#.#\n..#\n# . #\n. . #\n1\t-2\n3\t4\n"
+	if buf.String() != want {
+		t.Fatalf("unexpected output.\nwant: %q\ngot:  %q", want, buf.String())
+	}
+	if err := w.WriteMatrix([]int64{1, 2, 3}, 2, " "); !errors.Is(err, ErrRowWidth) {
+		t.Fatalf("Expected ErrRowWidth, got: %v", err)
+	}
+}
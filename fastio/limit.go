@@ -0,0 +1,113 @@
+package fastio
+
+import (
+	"bytes"
+	"errors"
+	"io"
+)
+
+// ErrTokenTooLong возвращается, когда слово длиннее WithMaxTokenSize
+// или строка длиннее WithMaxLineSize.
+var ErrTokenTooLong = errors.New("token too long")
+
+// LongTokenMode задаёт, что делать с остатком слишком длинного токена,
+// см. WithLongTokenMode.
+type LongTokenMode int
+
+const (
+	// LongTokenDiscard пропускает слово до разделителя, а строку —
+	// до '\n' включительно; следующее чтение начинается после них.
+	// Пропуск идёт по буферу и не требует памяти.
+	LongTokenDiscard LongTokenMode = iota
+	// LongTokenLeave оставляет остаток во входе: первые limit байт токена
+	// считаются прочитанными, и следующий вызов вернёт продолжение.
+	// Так длинную строку можно дочитать частями.
+	LongTokenLeave
+)
+
+// WithMaxTokenSize ограничивает длину слова n байтами для NextWord,
+// NextWordBytes, целых (NextInt, NextIntBase, NextBigInt) и методов,
+// читающих токен целиком: NextFloat64, NextBool, NextDecimal, Scan,
+// Decode и т. д. Более длинное слово
+// возвращается как *ParseError с ErrTokenTooLong вместо того, чтобы
+// собирать его в памяти целиком. Неположительное n снимает ограничение
+// (по умолчанию). На FastWriter опция не влияет.
+func WithMaxTokenSize(n int) Option {
+	return func(o *options) {
+		o.maxToken = n
+	}
+}
+
+// WithMaxLineSize ограничивает длину строки NextLine и NextLineBytes
+// n байтами без завершающего '\n' ('\r' перед ним учитывается).
+// Более длинная строка возвращается как *ParseError с ErrTokenTooLong.
+// Тот же лимит действует на запись CSVReader.NextRecord, а лимит
+// WithMaxTokenSize — на каждое её поле. Неположительное n снимает
+// ограничение (по умолчанию). На FastWriter опция не влияет.
+func WithMaxLineSize(n int) Option {
+	return func(o *options) {
+		o.maxLine = n
+	}
+}
+
+// WithLongTokenMode задаёт, что делать с остатком токена, превысившего
+// WithMaxTokenSize или WithMaxLineSize; по умолчанию LongTokenDiscard.
+// На FastWriter опция не влияет.
+func WithLongTokenMode(mode LongTokenMode) Option {
+	return func(o *options) {
+		o.longToken = mode
+	}
+}
+
+// tokenExceeded сообщает, что прочитанная часть токена, начавшегося
+// со смещения start, длиннее WithMaxTokenSize. Проверка делается до
+// fill(), пока смещение start+maxToken ещё в buf (см. tokenTooLong).
+func (fr *FastReader) tokenExceeded(start int64) bool {
+	return fr.maxToken > 0 && fr.offset()-start > int64(fr.maxToken)
+}
+
+// tokenTooLong возвращает *ParseError с ErrTokenTooLong для токена
+// (строки при line), который начинается со смещения start и длиннее
+// limit байт; head — уже прочитанное начало токена. Смещение start+limit
+// должно оставаться в buf: при LongTokenLeave позиция ставится на него,
+// иначе токен дочитывается до конца без сохранения.
+func (fr *FastReader) tokenTooLong(fn string, start int64, head []byte, limit int, line bool) error {
+	if len(head) > maxTokenPreview {
+		head = head[:maxTokenPreview]
+	}
+	err := fr.parseError(fn, start, string(head), ErrTokenTooLong)
+	if fr.longToken == LongTokenLeave {
+		fr.pos = int(start + int64(limit) - fr.off)
+		return err
+	}
+	for {
+		if line {
+			if i := bytes.IndexByte(fr.buf[fr.pos:fr.n], '\n'); i >= 0 {
+				fr.pos += i + 1
+				return err
+			}
+			fr.pos = fr.n
+		} else {
+			done := false
+			if fr.unicode {
+				fr.pos, done = fr.scanWordUnicode(fr.pos)
+			} else {
+				for fr.pos < fr.n && !fr.delims[fr.buf[fr.pos]] {
+					fr.pos++
+				}
+				done = fr.pos < fr.n
+			}
+			if done {
+				return err
+			}
+		}
+		if fr.err != nil {
+			fr.pos = fr.n
+			if !errors.Is(fr.err, io.EOF) {
+				return fr.err
+			}
+			return err
+		}
+		fr.fill()
+	}
+}
@@ -0,0 +1,202 @@
+package fastio
+
+import (
+	"errors"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// readAll читает входные данные функцией next до io.EOF и возвращает
+// результаты; ошибка ErrTokenTooLong записывается как "!".
+func readAll(t *testing.T, next func() ([]byte, error)) []string {
+	t.Helper()
+	var got []string
+	for i := 0; i < 100; i++ {
+		b, err := next()
+		switch {
+		case err == io.EOF:
+			return got
+		case errors.Is(err, ErrTokenTooLong):
+			got = append(got, "!")
+		case err != nil:
+			t.Fatalf("unexpected error: %v", err)
+		default:
+			got = append(got, string(b))
+		}
+	}
+	t.Fatal("reader does not reach EOF")
+	return nil
+}
+
+func TestMaxTokenSize(t *testing.T) {
+	long := strings.Repeat("x", 40)
+	input := "ab " + long + " cd abcdef\n" + long
+	tests := []struct {
+		mode LongTokenMode
+		want []string
+	}{
+		{LongTokenDiscard, []string{"ab", "!", "cd", "abcdef", "!"}},
+		{LongTokenLeave, []string{"ab", "!", "!", "!", "!", "xxxx", "cd", "abcdef", "!", "!", "!", "!", "xxxx"}},
+	}
+	for _, tt := range tests {
+		for _, unicode := range []bool{false, true} {
+			readers := map[string]io.Reader{
+				"plain":   strings.NewReader(input),
+				"onebyte": iotest.OneByteReader(strings.NewReader(input)),
+			}
+			for name, src := range readers {
+				opts := []Option{WithBufferSize(16), WithMaxTokenSize(9), WithLongTokenMode(tt.mode)}
+				if unicode {
+					opts = append(opts, WithUnicodeSpaces())
+				}
+				r := NewReader(src, opts...)
+				got := readAll(t, r.NextWordBytes)
+				if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+					t.Errorf("mode %d, %s, unicode %v: got %q; want %q", tt.mode, name, unicode, got, tt.want)
+				}
+				if len(r.buf) > 32 {
+					t.Errorf("mode %d, %s, unicode %v: buffer grew to %d", tt.mode, name, unicode, len(r.buf))
+				}
+			}
+		}
+	}
+}
+
+func TestMaxTokenSizeParseError(t *testing.T) {
+	r := NewReader(strings.NewReader("1.5\n  123456789012"), WithMaxTokenSize(8))
+	if _, err := r.NextFloat64(); err != nil {
+		t.Fatalf("NextFloat64 error: %v", err)
+	}
+	_, err := r.NextFloat64()
+	var pe *ParseError
+	if !errors.As(err, &pe) || !errors.Is(err, ErrTokenTooLong) {
+		t.Fatalf("NextFloat64 error = %v; want *ParseError with ErrTokenTooLong", err)
+	}
+	if pe.Func != "NextFloat64" || pe.Offset != 6 || pe.Line != 2 || pe.Col != 3 || pe.Token != "123456789012" {
+		t.Errorf("ParseError = %+v", *pe)
+	}
+}
+
+func TestMaxTokenSizeIntegers(t *testing.T) {
+	long := strings.Repeat("7", 40)
+	input := "12 " + long + " -" + long + " 34\n"
+	readers := map[string]func([]Option) (func() ([]byte, error), *FastReader){
+		"NextInt": func(opts []Option) (func() ([]byte, error), *FastReader) {
+			r := NewReader(strings.NewReader(input), opts...)
+			return func() ([]byte, error) {
+				v, err := r.NextInt()
+				return strconv.AppendInt(nil, int64(v), 10), err
+			}, r
+		},
+		"NextIntBase": func(opts []Option) (func() ([]byte, error), *FastReader) {
+			r := NewReader(iotest.OneByteReader(strings.NewReader(input)), opts...)
+			return func() ([]byte, error) {
+				v, err := r.NextIntBase(0)
+				return strconv.AppendInt(nil, v, 10), err
+			}, r
+		},
+		"NextBigInt": func(opts []Option) (func() ([]byte, error), *FastReader) {
+			r := NewReader(iotest.OneByteReader(strings.NewReader(input)), opts...)
+			var v big.Int
+			return func() ([]byte, error) {
+				err := r.NextBigInt(&v)
+				return v.Append(nil, 10), err
+			}, r
+		},
+	}
+	for name, open := range readers {
+		next, r := open([]Option{WithBufferSize(16), WithMaxTokenSize(16)})
+		var got []string
+		for {
+			b, err := next()
+			if err == io.EOF {
+				break
+			}
+			var pe *ParseError
+			switch {
+			case errors.As(err, &pe) && errors.Is(err, ErrTokenTooLong):
+				if len(pe.Token) > maxTokenPreview {
+					t.Errorf("%s: ParseError.Token = %q; want at most %d bytes", name, pe.Token, maxTokenPreview)
+				}
+				got = append(got, "!")
+			case err != nil:
+				t.Fatalf("%s: unexpected error: %v", name, err)
+			default:
+				got = append(got, string(b))
+			}
+			if len(got) > 10 {
+				t.Fatalf("%s: reader does not reach EOF: %q", name, got)
+			}
+		}
+		want := "12,!,!,34"
+		if strings.Join(got, ",") != want {
+			t.Errorf("%s: got %q; want %q", name, got, want)
+		}
+		if len(r.buf) > 32 || cap(r.tok) > maxTokenPreview {
+			t.Errorf("%s: buffer grew to %d, scratch to %d", name, len(r.buf), cap(r.tok))
+		}
+	}
+}
+
+func TestIntegerOverflowPreview(t *testing.T) {
+	long := strings.Repeat("9", 1000)
+	for _, base := range []int{10, 0} {
+		r := NewReader(strings.NewReader(long+" 5"), WithBufferSize(16))
+		_, err := r.NextIntBase(base)
+		var pe *ParseError
+		if !errors.As(err, &pe) || !errors.Is(err, ErrRange) {
+			t.Fatalf("base %d: error = %v; want *ParseError with ErrRange", base, err)
+		}
+		if pe.Token != long[:maxTokenPreview] {
+			t.Errorf("base %d: ParseError.Token = %q; want %d-byte preview", base, pe.Token, maxTokenPreview)
+		}
+		if v, err := r.NextInt(); err != nil || v != 5 {
+			t.Errorf("base %d: next NextInt = %d, %v; want 5", base, v, err)
+		}
+	}
+}
+
+func TestMaxLineSize(t *testing.T) {
+	long := strings.Repeat("y", 25)
+	input := "short\n" + long + "\nabcdef\r\n\n" + long
+	tests := []struct {
+		mode LongTokenMode
+		want []string
+	}{
+		{LongTokenDiscard, []string{"short", "!", "abcdef", "", "!"}},
+		{LongTokenLeave, []string{"short", "!", "!", "!", "y", "abcdef", "", "!", "!", "!", "y"}},
+	}
+	for _, tt := range tests {
+		readers := map[string]io.Reader{
+			"plain":   strings.NewReader(input),
+			"onebyte": iotest.OneByteReader(strings.NewReader(input)),
+		}
+		for name, src := range readers {
+			r := NewReader(src, WithBufferSize(16), WithMaxLineSize(8), WithLongTokenMode(tt.mode))
+			got := readAll(t, r.NextLineBytes)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("mode %d, %s: got %q; want %q", tt.mode, name, got, tt.want)
+			}
+			if len(r.buf) != 16 || cap(r.tok) > 16 {
+				t.Errorf("mode %d, %s: buffer grew to %d, scratch to %d", tt.mode, name, len(r.buf), cap(r.tok))
+			}
+		}
+	}
+}
+
+func TestMaxLineSizeDoesNotLimitWords(t *testing.T) {
+	r := NewReader(strings.NewReader("abcdefgh ij klm\n"), WithMaxLineSize(4))
+	w, err := r.NextWord()
+	if err != nil || w != "abcdefgh" {
+		t.Fatalf("NextWord = %q, %v; want %q", w, err, "abcdefgh")
+	}
+	_, err = r.NextLine()
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.Func != "NextLine" || !errors.Is(err, ErrTokenTooLong) {
+		t.Fatalf("NextLine error = %v; want ParseError from NextLine with ErrTokenTooLong", err)
+	}
+}
@@ -0,0 +1,79 @@
+package fastio
+
+import "errors"
+
+var (
+	// ErrInvalidMark возвращается ResetToMark, если данные контрольной
+	// точки уже вытеснены из буфера (например, после Unmark).
+	ErrInvalidMark = errors.New("invalid mark")
+	// ErrInvalidUnread возвращается UnreadByte и UnreadRune, если
+	// после последнего ReadByte/ReadRune было другое чтение или откат.
+	ErrInvalidUnread = errors.New("invalid use of unread")
+)
+
+// Checkpoint — контрольная точка во входном потоке, см. Mark.
+type Checkpoint struct {
+	off int64
+}
+
+// Offset возвращает абсолютное смещение контрольной точки в байтах.
+func (c Checkpoint) Offset() int64 {
+	return c.off
+}
+
+// Mark запоминает текущую позицию и возвращает контрольную точку,
+// к которой можно вернуться через ResetToMark. Пока действует самая
+// ранняя из поставленных точек, fill() не вытесняет данные после неё,
+// и буфер при необходимости растёт, поэтому после удачного разбора
+// вызывайте Unmark.
+//
+//	m := fr.Mark()
+//	if v, err := fr.NextInt(); err == nil {
+//		fr.Unmark()
+//		use(v)
+//	} else {
+//		fr.ResetToMark(m)
+//		word, _ := fr.NextWord()
+//		...
+//	}
+func (fr *FastReader) Mark() Checkpoint {
+	off := fr.offset()
+	if !fr.marked || off < fr.mark {
+		fr.marked = true
+		fr.mark = off
+	}
+	return Checkpoint{off: off}
+}
+
+// ResetToMark возвращает чтение к контрольной точке m. Точки остаются
+// действительными до Unmark, поэтому к одной точке можно возвращаться
+// несколько раз. Ошибка io.EOF, встреченная после m, сбрасывается
+// вместе с позицией; прочие ошибки чтения остаются.
+func (fr *FastReader) ResetToMark(m Checkpoint) error {
+	if m.off < fr.off || m.off > fr.off+int64(fr.n) {
+		return ErrInvalidMark
+	}
+	fr.pos = int(m.off - fr.off)
+	fr.lastRead = 0
+	return nil
+}
+
+// Unmark снимает все контрольные точки и разрешает fill() снова
+// вытеснять прочитанные данные. Уже выданные точки становятся
+// недействительными, как только их данные покинут буфер.
+func (fr *FastReader) Unmark() {
+	fr.marked = false
+}
+
+// UnreadByte отменяет последний ReadByte, так что FastReader
+// удовлетворяет io.ByteScanner. Допустим только сразу после ReadByte
+// или ReadRune (во втором случае возвращается последний байт руны);
+// иначе возвращается ErrInvalidUnread.
+func (fr *FastReader) UnreadByte() error {
+	if fr.lastRead == 0 || fr.lastEnd != fr.offset() || fr.pos < 1 {
+		return ErrInvalidUnread
+	}
+	fr.pos--
+	fr.lastRead = 0
+	return nil
+}
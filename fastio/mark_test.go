@@ -0,0 +1,159 @@
+package fastio
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"unicode/utf8"
+)
+
+var (
+	_ io.ByteScanner = (*FastReader)(nil)
+	_ io.RuneScanner = (*FastReader)(nil)
+)
+
+func TestMarkResetFallsBackToWord(t *testing.T) {
+	long := strings.Repeat("x", 100)
+	in := "12 " + long + "\n34"
+	for _, r := range []*FastReader{
+		newTestReader(in),
+		NewReader(iotest.OneByteReader(strings.NewReader(in)), WithBufferSize(16)),
+	} {
+		var got []string
+		for {
+			m := r.Mark()
+			v, err := r.NextInt()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err == nil {
+				r.Unmark()
+				got = append(got, "int")
+				if v != 12 && v != 34 {
+					t.Fatalf("NextInt = %d", v)
+				}
+				continue
+			}
+			if err := r.ResetToMark(m); err != nil {
+				t.Fatalf("ResetToMark error: %v", err)
+			}
+			w, err := r.NextWord()
+			if err != nil || w != long {
+				t.Fatalf("NextWord after reset = %q, %v", w, err)
+			}
+			r.Unmark()
+			got = append(got, "word")
+		}
+		if strings.Join(got, ",") != "int,word,int" {
+			t.Fatalf("tokens = %v; want int,word,int", got)
+		}
+	}
+}
+
+func TestMarkRetainsDataAcrossFills(t *testing.T) {
+	in := "a\nbc\n" + strings.Repeat("d", 200) + "\n" + strings.Repeat("e", 1000) + "\nend"
+	r := NewReader(iotest.OneByteReader(strings.NewReader(in)), WithBufferSize(16))
+	if _, err := r.NextLine(); err != nil {
+		t.Fatalf("NextLine error: %v", err)
+	}
+	m := r.Mark()
+	if m.Offset() != 2 {
+		t.Fatalf("Mark offset = %d; want 2", m.Offset())
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := r.NextLine(); err != nil {
+			t.Fatalf("NextLine error: %v", err)
+		}
+	}
+	if err := r.ResetToMark(m); err != nil {
+		t.Fatalf("ResetToMark error: %v", err)
+	}
+	if p := r.Position(); p.Offset != 2 || p.Line != 2 || p.Col != 1 {
+		t.Fatalf("Position after reset = %+v; want offset 2 at 2:1", p)
+	}
+	// К одной точке можно вернуться повторно.
+	if _, err := r.NextLine(); err != nil {
+		t.Fatalf("NextLine error: %v", err)
+	}
+	if err := r.ResetToMark(m); err != nil {
+		t.Fatalf("second ResetToMark error: %v", err)
+	}
+	line, err := r.NextLine()
+	if err != nil || line != "bc" {
+		t.Fatalf("NextLine after reset = %q, %v; want bc", line, err)
+	}
+
+	r.Unmark()
+	for i := 0; i < 2; i++ {
+		if _, err := r.NextLine(); err != nil {
+			t.Fatalf("NextLine error: %v", err)
+		}
+	}
+	if err := r.ResetToMark(m); !errors.Is(err, ErrInvalidMark) {
+		t.Fatalf("Expected ErrInvalidMark after Unmark, got: %v", err)
+	}
+	if line, err := r.NextLine(); err != nil || line != "end" {
+		t.Fatalf("NextLine = %q, %v; want end", line, err)
+	}
+}
+
+func TestUnreadByte(t *testing.T) {
+	r := NewReader(iotest.OneByteReader(strings.NewReader("ab c")), WithBufferSize(16))
+	if err := r.UnreadByte(); !errors.Is(err, ErrInvalidUnread) {
+		t.Fatalf("UnreadByte before read: expected ErrInvalidUnread, got: %v", err)
+	}
+	for _, want := range []byte("ab") {
+		b, err := r.ReadByte()
+		if err != nil || b != want {
+			t.Fatalf("ReadByte = %q, %v; want %q", b, err, want)
+		}
+		if err := r.UnreadByte(); err != nil {
+			t.Fatalf("UnreadByte error: %v", err)
+		}
+		if err := r.UnreadByte(); !errors.Is(err, ErrInvalidUnread) {
+			t.Fatalf("second UnreadByte: expected ErrInvalidUnread, got: %v", err)
+		}
+		if b, _ := r.ReadByte(); b != want {
+			t.Fatalf("ReadByte after unread = %q; want %q", b, want)
+		}
+	}
+	if _, err := r.ReadByte(); err != nil {
+		t.Fatalf("ReadByte error: %v", err)
+	}
+	if w, err := r.NextWord(); err != nil || w != "c" {
+		t.Fatalf("NextWord = %q, %v; want c", w, err)
+	}
+	if err := r.UnreadByte(); !errors.Is(err, ErrInvalidUnread) {
+		t.Fatalf("UnreadByte after NextWord: expected ErrInvalidUnread, got: %v", err)
+	}
+}
+
+func TestReadRuneAndUnreadRune(t *testing.T) {
+	in := "aж€𝄞\xffz"
+	want := []rune{'a', 'ж', '€', '𝄞', utf8.RuneError, 'z'}
+	for _, r := range []*FastReader{
+		newTestReader(in),
+		NewReader(iotest.OneByteReader(strings.NewReader(in)), WithBufferSize(16)),
+	} {
+		for _, w := range want {
+			c, size, err := r.ReadRune()
+			if err != nil || c != w {
+				t.Fatalf("ReadRune = %q, %v; want %q", c, err, w)
+			}
+			if w == utf8.RuneError && size != 1 {
+				t.Fatalf("ReadRune size for invalid byte = %d; want 1", size)
+			}
+			if err := r.UnreadRune(); err != nil {
+				t.Fatalf("UnreadRune error: %v", err)
+			}
+			if c, _, _ := r.ReadRune(); c != w {
+				t.Fatalf("ReadRune after unread = %q; want %q", c, w)
+			}
+		}
+		if _, _, err := r.ReadRune(); !errors.Is(err, io.EOF) {
+			t.Fatalf("Expected EOF error, got: %v", err)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package fastio
+
+import (
+	"errors"
+	"io"
+	"math"
+	"os"
+)
+
+// MmapReader — FastReader поверх файла, отображённого в память.
+//
+// Все методы FastReader (NextInt, NextWord, NextLine, ...) работают
+// прямо по отображённым байтам, без копирования в буфер: fill() не
+// вызывается ни разу. Срезы NextWordBytes и NextLineBytes действительны
+// до Close.
+//
+// Если отобразить файл нельзя (канал, устройство, пустой файл или
+// платформа без mmap), MmapReader читает его обычным буферизованным путём.
+type MmapReader struct {
+	*FastReader
+
+	data []byte
+	file *os.File
+}
+
+// OpenMmap открывает файл path для чтения. Обычные файлы на Linux
+// отображаются в память через syscall.Mmap; для остальных используется
+// NewReader(file, opts...). Опции разделителей и Unicode действуют
+// в обоих случаях, WithBufferSize — только в буферизованном.
+//
+// После работы вызовите Close.
+func OpenMmap(path string, opts ...Option) (*MmapReader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	st, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	if size := st.Size(); st.Mode().IsRegular() && size > 0 && size <= math.MaxInt {
+		if data, err := mmapFile(f, int(size)); err == nil {
+			// Отображение остаётся действительным и после закрытия файла.
+			_ = f.Close()
+			o := newOptions(defaultReaderBufSize, opts)
+			fr := newReader(nil, data, o)
+			fr.n = len(data)
+			// Данных больше не будет: fill() не вызывается. Err()
+			// сообщит io.EOF, только когда позиция дойдёт до конца.
+			fr.err = io.EOF
+			fr.mapped = true
+			return &MmapReader{FastReader: fr, data: data}, nil
+		}
+	}
+	return &MmapReader{FastReader: NewReader(f, opts...), file: f}, nil
+}
+
+// Mapped сообщает, отображён ли файл в память.
+func (mr *MmapReader) Mapped() bool {
+	return mr.data != nil
+}
+
+// Close снимает отображение или закрывает файл. После Close чтение
+// возвращает os.ErrClosed; повторный Close также возвращает os.ErrClosed.
+func (mr *MmapReader) Close() error {
+	if mr.data == nil && mr.file == nil {
+		return os.ErrClosed
+	}
+	var err error
+	if mr.data != nil {
+		// Перед снятием отображения отвязываем буфер ридера.
+		mr.buf, mr.pos, mr.n = nil, 0, 0
+		mr.mapped = false
+		err = munmap(mr.data)
+		mr.data = nil
+	}
+	if mr.file != nil {
+		err = errors.Join(err, mr.file.Close())
+		mr.file = nil
+	}
+	mr.err = os.ErrClosed
+	return err
+}
@@ -0,0 +1,23 @@
+//go:build linux
+
+package fastio
+
+import (
+	"os"
+	"syscall"
+)
+
+func mmapFile(f *os.File, size int) ([]byte, error) {
+	data, err := syscall.Mmap(int(f.Fd()), 0, size, syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, err
+	}
+	// Просим ядро заранее подгрузить страницы, чтобы первый проход
+	// по файлу не упирался в page fault на каждой странице.
+	_ = syscall.Madvise(data, syscall.MADV_WILLNEED)
+	return data, nil
+}
+
+func munmap(data []byte) error {
+	return syscall.Munmap(data)
+}
@@ -0,0 +1,18 @@
+//go:build !linux
+
+package fastio
+
+import (
+	"errors"
+	"os"
+)
+
+var errMmapUnsupported = errors.New("mmap is not supported on this platform")
+
+func mmapFile(f *os.File, size int) ([]byte, error) {
+	return nil, errMmapUnsupported
+}
+
+func munmap(data []byte) error {
+	return nil
+}
@@ -0,0 +1,171 @@
+package fastio
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func writeTempFile(t *testing.T, data string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("WriteFile error: %v", err)
+	}
+	return path
+}
+
+func TestOpenMmap(t *testing.T) {
+	var sb strings.Builder
+	for i := 0; i < 50000; i++ {
+		sb.WriteString("12345 -7\n")
+	}
+	sb.WriteString("word tail line\r\n")
+	path := writeTempFile(t, sb.String())
+
+	mr, err := OpenMmap(path)
+	if err != nil {
+		t.Fatalf("OpenMmap error: %v", err)
+	}
+	if runtime.GOOS == "linux" && !mr.Mapped() {
+		t.Fatalf("Mapped = false on linux")
+	}
+	sum := 0
+	for i := 0; i < 100000; i++ {
+		v, err := mr.NextInt()
+		if err != nil {
+			t.Fatalf("NextInt #%d error: %v", i, err)
+		}
+		sum += v
+	}
+	if sum != 50000*(12345-7) {
+		t.Fatalf("sum = %d; want %d", sum, 50000*(12345-7))
+	}
+	if w, err := mr.NextWord(); err != nil || w != "word" {
+		t.Fatalf("NextWord = %q, %v; want word", w, err)
+	}
+	if l, err := mr.NextLine(); err != nil || l != " tail line" {
+		t.Fatalf("NextLine = %q, %v; want %q", l, err, " tail line")
+	}
+	if _, err := mr.NextInt(); !errors.Is(err, io.EOF) {
+		t.Fatalf("Expected EOF error, got: %v", err)
+	}
+	if p := mr.Position(); p.Line != 50002 || p.Col != 1 {
+		t.Fatalf("Position = %+v; want line 50002, col 1", p)
+	}
+
+	if err := mr.Close(); err != nil {
+		t.Fatalf("Close error: %v", err)
+	}
+	if _, err := mr.NextInt(); !errors.Is(err, os.ErrClosed) {
+		t.Fatalf("NextInt after Close: expected os.ErrClosed, got: %v", err)
+	}
+	if err := mr.Close(); !errors.Is(err, os.ErrClosed) {
+		t.Fatalf("second Close: expected os.ErrClosed, got: %v", err)
+	}
+}
+
+func TestMmapReaderErr(t *testing.T) {
+	path := writeTempFile(t, "1 2\n")
+	mr, err := OpenMmap(path)
+	if err != nil {
+		t.Fatalf("OpenMmap error: %v", err)
+	}
+	defer mr.Close()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Open error: %v", err)
+	}
+	defer f.Close()
+	// MmapReader и NewReader по тому же файлу сообщают io.EOF одинаково:
+	// только после того, как прочитаны все данные.
+	for name, r := range map[string]*FastReader{"mmap": mr.FastReader, "file": NewReader(f)} {
+		if err := r.Err(); err != nil {
+			t.Errorf("%s: Err() after open = %v; want nil", name, err)
+		}
+		_, _ = r.NextInt()
+		if err := r.Err(); err != nil {
+			t.Errorf("%s: Err() after first number = %v; want nil", name, err)
+		}
+		_, _ = r.NextInt()
+		if err := r.Err(); err != nil {
+			t.Errorf("%s: Err() before trailing newline = %v; want nil", name, err)
+		}
+		if _, err := r.NextInt(); err != io.EOF || r.Err() != io.EOF {
+			t.Errorf("%s: NextInt at end = %v, Err() = %v; want io.EOF", name, err, r.Err())
+		}
+	}
+}
+
+func TestOpenMmapOptionsAndMark(t *testing.T) {
+	mr, err := OpenMmap(writeTempFile(t, "1;2;x"), WithDelimiters(NewDelimiters(";")))
+	if err != nil {
+		t.Fatalf("OpenMmap error: %v", err)
+	}
+	defer mr.Close()
+	m := mr.Mark()
+	a, _ := mr.NextInt()
+	b, _ := mr.NextInt()
+	if a != 1 || b != 2 {
+		t.Fatalf("NextInt = %d, %d; want 1, 2", a, b)
+	}
+	if err := mr.ResetToMark(m); err != nil {
+		t.Fatalf("ResetToMark error: %v", err)
+	}
+	if w, _ := mr.NextWord(); w != "1" {
+		t.Fatalf("NextWord after reset = %q; want 1", w)
+	}
+}
+
+func TestOpenMmapFallback(t *testing.T) {
+	// Пустой файл отобразить нельзя: используется обычное чтение.
+	mr, err := OpenMmap(writeTempFile(t, ""))
+	if err != nil {
+		t.Fatalf("OpenMmap error: %v", err)
+	}
+	if mr.Mapped() {
+		t.Fatalf("empty file must not be mapped")
+	}
+	if _, err := mr.NextInt(); !errors.Is(err, io.EOF) {
+		t.Fatalf("Expected EOF error, got: %v", err)
+	}
+	if err := mr.Close(); err != nil {
+		t.Fatalf("Close error: %v", err)
+	}
+
+	if _, err := OpenMmap(filepath.Join(t.TempDir(), "missing")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("Expected os.ErrNotExist, got: %v", err)
+	}
+
+	if runtime.GOOS == "linux" {
+		// Символьное устройство — не обычный файл.
+		mr, err := OpenMmap("/dev/null")
+		if err != nil {
+			t.Fatalf("OpenMmap(/dev/null) error: %v", err)
+		}
+		defer mr.Close()
+		if mr.Mapped() {
+			t.Fatalf("/dev/null must not be mapped")
+		}
+		if _, err := mr.NextWord(); !errors.Is(err, io.EOF) {
+			t.Fatalf("Expected EOF error, got: %v", err)
+		}
+	}
+}
+
+func TestMmapReaderReset(t *testing.T) {
+	mr, err := OpenMmap(writeTempFile(t, "1 2"))
+	if err != nil {
+		t.Fatalf("OpenMmap error: %v", err)
+	}
+	defer mr.Close()
+	// Reset на ридере с отображением не должен писать в отображённую память.
+	mr.Reset(strings.NewReader("42"))
+	if v, err := mr.NextInt(); err != nil || v != 42 {
+		t.Fatalf("NextInt after Reset = %d, %v; want 42", v, err)
+	}
+}
@@ -0,0 +1,70 @@
+package fastio
+
+import "context"
+
+const minBufSize = 16
+
+// Option настраивает FastReader или FastWriter при создании.
+// Передаётся в NewReader, NewWriter и NewWriterWithAutoFlush.
+//
+// Опции, не относящиеся к создаваемому типу, игнорируются:
+// например, WithAutoFlush не влияет на FastReader.
+type Option func(*options)
+
+type options struct {
+	bufSize    int
+	autoFlush  bool
+	flushLimit int
+	delims     *Delimiters
+
+	unicodeSpaces bool
+	invalidUTF8   InvalidUTF8Mode
+
+	maxToken  int
+	maxLine   int
+	longToken LongTokenMode
+
+	ctx context.Context
+}
+
+func newOptions(defaultBufSize int, opts []Option) options {
+	var o options
+	if len(opts) > 0 {
+		// Отдельная переменная, чтобы без опций не было аллокации.
+		p := new(options)
+		for _, opt := range opts {
+			opt(p)
+		}
+		o = *p
+	}
+	if o.bufSize <= 0 {
+		o.bufSize = defaultBufSize
+	}
+	if o.bufSize < minBufSize {
+		o.bufSize = minBufSize
+	}
+	return o
+}
+
+// WithBufferSize задаёт размер внутреннего буфера в байтах.
+// По умолчанию используется 64 KB; значения меньше 16 байт
+// увеличиваются до 16, неположительные — заменяются на значение по умолчанию.
+//
+// Большие буферы (несколько мегабайт) уменьшают число системных
+// вызовов при обработке больших файлов, маленькие экономят память.
+func WithBufferSize(n int) Option {
+	return func(o *options) {
+		o.bufSize = n
+	}
+}
+
+// WithAutoFlush включает автоматический сброс буфера FastWriter,
+// когда размер данных достигает limit байт.
+// Если limit не положителен или больше размера буфера,
+// используется половина размера буфера.
+func WithAutoFlush(limit int) Option {
+	return func(o *options) {
+		o.autoFlush = true
+		o.flushLimit = limit
+	}
+}
@@ -0,0 +1,294 @@
+package fastio
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// parallelChunkSize — размер порции входа, которую разбирает один воркер.
+// Переменная, а не константа, чтобы тесты могли дробить вход мельче.
+var parallelChunkSize = 4 << 20
+
+// ParallelInts разбирает файл path, состоящий из целых чисел через
+// пробельные символы, на workers горутинах (при workers <= 0 —
+// runtime.GOMAXPROCS(0)).
+//
+// Файл открывается через OpenMmap и делится на порции около 4 MB,
+// выровненные по пробельным символам. Каждая порция разбирается тем же
+// ядром, что и NextInt64, и передаётся в mapFn, который вызывается
+// параллельно из разных горутин. Результаты mapFn передаются в reduce
+// последовательно и в порядке следования порций в файле, поэтому reduce
+// может без блокировок накапливать общий итог:
+//
+//	var total int64
+//	err := fastio.ParallelInts(path, 0,
+//		func(vals []int64) int64 {
+//			var s int64
+//			for _, v := range vals {
+//				s += v
+//			}
+//			return s
+//		},
+//		func(s int64) { total += s })
+//
+// Срез vals переиспользуется после возврата из mapFn: сохранять его нельзя.
+// Ошибка разбора возвращается как *ParseError с абсолютной позицией
+// (Func = "ParallelInts"); при нескольких ошибках — самая ранняя во входе.
+// Результаты порций до неё успевают попасть в reduce.
+func ParallelInts[R any](path string, workers int, mapFn func(vals []int64) R, reduce func(R)) error {
+	return parallelInts(path, workers, false, mapFn,
+		func(r R, _ []int64) error {
+			reduce(r)
+			return nil
+		})
+}
+
+// ParallelIntsOrdered разбирает файл так же, как ParallelInts, но
+// передаёт в fn сами числа — последовательно и в исходном порядке,
+// порциями. Срез vals действителен только до возврата из fn.
+// Ошибка fn прекращает разбор и возвращается как есть.
+func ParallelIntsOrdered(path string, workers int, fn func(vals []int64) error) error {
+	return parallelInts(path, workers, true,
+		func([]int64) struct{} { return struct{}{} },
+		func(_ struct{}, vals []int64) error { return fn(vals) })
+}
+
+// intChunk — порция входа вместе с позицией её начала.
+type intChunk struct {
+	idx       int
+	data      []byte
+	buf       *[]byte // буфер из chunkBufPool для чтения без mmap
+	off       int64
+	lines     int
+	lineStart int64
+}
+
+type intResult[R any] struct {
+	idx  int
+	res  R
+	vals *[]int64
+	err  error
+}
+
+var (
+	chunkBufPool = sync.Pool{New: func() any { return new([]byte) }}
+	chunkValPool = sync.Pool{New: func() any { return new([]int64) }}
+)
+
+// parallelInts — общее ядро ParallelInts и ParallelIntsOrdered.
+// При ownVals срез чисел живёт до вызова reduce, иначе возвращается
+// в пул сразу после mapFn.
+func parallelInts[R any](path string, workers int, ownVals bool, mapFn func([]int64) R, reduce func(R, []int64) error) error {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	mr, err := OpenMmap(path)
+	if err != nil {
+		return err
+	}
+	defer mr.Close()
+
+	var stop atomic.Bool
+	// inflight ограничивает число порций, ещё не переданных в reduce,
+	// чтобы упорядочивание не копило результаты без предела.
+	inflight := make(chan struct{}, 2*workers)
+	chunks := make(chan intChunk)
+	results := make(chan intResult[R], workers)
+
+	var prodErr error
+	go func() {
+		defer close(chunks)
+		send := func(c intChunk) bool {
+			inflight <- struct{}{}
+			if stop.Load() {
+				<-inflight
+				return false
+			}
+			chunks <- c
+			return true
+		}
+		if mr.Mapped() {
+			splitMapped(mr.data, send)
+		} else {
+			prodErr = splitStream(mr.file, send)
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fr := newReader(nil, nil, options{})
+			for c := range chunks {
+				r := intResult[R]{idx: c.idx, vals: chunkValPool.Get().(*[]int64)}
+				if !stop.Load() {
+					*r.vals, r.err = parseIntChunk(fr, c, (*r.vals)[:0])
+					if r.err == nil {
+						r.res = mapFn(*r.vals)
+					}
+				}
+				if c.buf != nil {
+					chunkBufPool.Put(c.buf)
+				}
+				if !ownVals {
+					chunkValPool.Put(r.vals)
+					r.vals = nil
+				}
+				results <- r
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	// Результаты приходят в произвольном порядке; pending хранит
+	// опередившие порции, пока не придёт очередь next.
+	pending := make(map[int]intResult[R])
+	next := 0
+	var firstErr error
+	errIdx := math.MaxInt
+	for r := range results {
+		if r.err != nil && r.idx < errIdx {
+			firstErr, errIdx = r.err, r.idx
+			stop.Store(true)
+		}
+		pending[r.idx] = r
+		for {
+			p, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			next++
+			if p.err == nil && !stop.Load() {
+				var vals []int64
+				if p.vals != nil {
+					vals = *p.vals
+				}
+				if err := reduce(p.res, vals); err != nil {
+					firstErr, errIdx = err, p.idx
+					stop.Store(true)
+				}
+			}
+			if p.vals != nil {
+				chunkValPool.Put(p.vals)
+			}
+			<-inflight
+		}
+	}
+	if firstErr != nil {
+		return firstErr
+	}
+	return prodErr
+}
+
+// parseIntChunk разбирает порцию ядром NextInt64, настраивая fr так,
+// чтобы позиции ошибок были абсолютными.
+func parseIntChunk(fr *FastReader, c intChunk, vals []int64) ([]int64, error) {
+	fr.buf, fr.pos, fr.n, fr.err = c.data, 0, len(c.data), io.EOF
+	fr.off, fr.lines, fr.lineStart = c.off, c.lines, c.lineStart
+	defer func() { fr.buf = nil }()
+	for {
+		v, neg, err := fr.nextInteger("ParallelInts", true, math.MaxInt64)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return vals, nil
+			}
+			return vals, err
+		}
+		if neg {
+			vals = append(vals, int64(-v))
+		} else {
+			vals = append(vals, int64(v))
+		}
+	}
+}
+
+// chunkPos отслеживает смещение и номер строки начала следующей порции.
+type chunkPos struct {
+	idx       int
+	off       int64
+	lines     int
+	lineStart int64
+}
+
+func (p *chunkPos) next(data []byte, buf *[]byte) intChunk {
+	c := intChunk{idx: p.idx, data: data, buf: buf, off: p.off, lines: p.lines, lineStart: p.lineStart}
+	if n := bytes.Count(data, []byte{'\n'}); n > 0 {
+		p.lines += n
+		p.lineStart = p.off + int64(bytes.LastIndexByte(data, '\n')) + 1
+	}
+	p.off += int64(len(data))
+	p.idx++
+	return c
+}
+
+// splitMapped делит отображённый файл на порции, заканчивая каждую
+// на первом пробельном символе после parallelChunkSize байт.
+func splitMapped(data []byte, send func(intChunk) bool) {
+	var p chunkPos
+	for start := 0; start < len(data); {
+		end := start + parallelChunkSize
+		if end >= len(data) {
+			end = len(data)
+		} else {
+			for end < len(data) && !defaultDelimiters[data[end]] {
+				end++
+			}
+		}
+		if !send(p.next(data[start:end], nil)) {
+			return
+		}
+		start = end
+	}
+}
+
+// splitStream читает r порциями для файлов, которые нельзя отобразить.
+// Незаконченный токен в конце порции переносится в следующую.
+func splitStream(r io.Reader, send func(intChunk) bool) error {
+	var p chunkPos
+	var carry []byte
+	for {
+		buf := chunkBufPool.Get().(*[]byte)
+		b := append((*buf)[:0], carry...)
+		if cap(b) < len(carry)+parallelChunkSize {
+			b = append(b, make([]byte, parallelChunkSize)...)[:len(carry)]
+		}
+		n, err := io.ReadFull(r, b[len(carry):len(carry)+parallelChunkSize])
+		b = b[:len(carry)+n]
+		*buf = b
+		eof := errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+		if err != nil && !eof {
+			chunkBufPool.Put(buf)
+			return err
+		}
+
+		end := len(b)
+		if !eof {
+			end = bytes.LastIndexAny(b, SpaceDelimiters) + 1
+		}
+		// carry копируется: b уходит воркеру и возвращается в пул.
+		carry = append(carry[:0], b[end:]...)
+		if end == 0 {
+			chunkBufPool.Put(buf)
+			if eof {
+				return nil
+			}
+			continue
+		}
+		if !send(p.next(b[:end], buf)) {
+			return nil
+		}
+		if eof {
+			return nil
+		}
+	}
+}
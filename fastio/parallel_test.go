@@ -0,0 +1,126 @@
+package fastio
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func withChunkSize(t *testing.T, n int) {
+	t.Helper()
+	old := parallelChunkSize
+	parallelChunkSize = n
+	t.Cleanup(func() { parallelChunkSize = old })
+}
+
+func TestParallelInts(t *testing.T) {
+	withChunkSize(t, 64)
+	var sb strings.Builder
+	want := int64(0)
+	for i := 0; i < 5000; i++ {
+		v := int64(i*7919%100003 - 50000)
+		want += v
+		sb.WriteString(strconv.FormatInt(v, 10))
+		if i%10 == 9 {
+			sb.WriteByte('\n')
+		} else {
+			sb.WriteByte(' ')
+		}
+	}
+	path := writeTempFile(t, sb.String())
+
+	for _, workers := range []int{0, 1, 4} {
+		var total int64
+		count := 0
+		err := ParallelInts(path, workers,
+			func(vals []int64) [2]int64 {
+				var s int64
+				for _, v := range vals {
+					s += v
+				}
+				return [2]int64{s, int64(len(vals))}
+			},
+			func(r [2]int64) {
+				total += r[0]
+				count += int(r[1])
+			})
+		if err != nil {
+			t.Fatalf("workers=%d: ParallelInts error: %v", workers, err)
+		}
+		if total != want || count != 5000 {
+			t.Fatalf("workers=%d: sum = %d, count = %d; want %d, 5000", workers, total, count, want)
+		}
+	}
+}
+
+func TestParallelIntsOrdered(t *testing.T) {
+	withChunkSize(t, 16)
+	var sb strings.Builder
+	for i := 0; i < 2000; i++ {
+		sb.WriteString(strconv.Itoa(i))
+		sb.WriteString("  \t")
+	}
+	path := writeTempFile(t, sb.String())
+
+	next := int64(0)
+	err := ParallelIntsOrdered(path, 4, func(vals []int64) error {
+		for _, v := range vals {
+			if v != next {
+				t.Fatalf("got %d; want %d", v, next)
+			}
+			next++
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ParallelIntsOrdered error: %v", err)
+	}
+	if next != 2000 {
+		t.Fatalf("read %d values; want 2000", next)
+	}
+
+	stop := errors.New("stop")
+	err = ParallelIntsOrdered(path, 4, func([]int64) error { return stop })
+	if err != stop {
+		t.Fatalf("Expected callback error, got: %v", err)
+	}
+}
+
+func TestParallelIntsError(t *testing.T) {
+	withChunkSize(t, 8)
+	var sb strings.Builder
+	for i := 0; i < 100; i++ {
+		sb.WriteString("1 2 3\n")
+	}
+	sb.WriteString("4 x5 6\n")
+	sb.WriteString("99999999999999999999\n")
+	path := writeTempFile(t, sb.String())
+
+	err := ParallelInts(path, 4, func([]int64) int { return 0 }, func(int) {})
+	var pe *ParseError
+	if !errors.As(err, &pe) || !errors.Is(err, ErrNoDigits) {
+		t.Fatalf("Expected *ParseError with ErrNoDigits, got: %v", err)
+	}
+	if pe.Func != "ParallelInts" || pe.Line != 101 || pe.Col != 3 || pe.Offset != 602 {
+		t.Fatalf("ParseError = %+v; want ParallelInts at 101:3, offset 602", pe)
+	}
+}
+
+func TestSplitStream(t *testing.T) {
+	withChunkSize(t, 5)
+	var got []string
+	err := splitStream(strings.NewReader("12 345\n6789012 7 "), func(c intChunk) bool {
+		got = append(got, string(c.data))
+		return true
+	})
+	if err != nil {
+		t.Fatalf("splitStream error: %v", err)
+	}
+	// Порции заканчиваются на пробельном символе, длинный токен
+	// переносится целиком.
+	want := []string{"12 ", "345\n", "6789012 ", "7 "}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("chunks = %q; want %q", got, want)
+	}
+}
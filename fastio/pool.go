@@ -0,0 +1,81 @@
+package fastio
+
+import (
+	"errors"
+	"io"
+	"sync"
+)
+
+// ErrUnflushed возвращается ReleaseWriter, если в буфере FastWriter
+// остались несброшенные данные.
+var ErrUnflushed = errors.New("writer has unflushed data")
+
+var (
+	readerPool = sync.Pool{
+		New: func() any { return NewReader(nil) },
+	}
+	writerPool = sync.Pool{
+		New: func() any { return NewWriter(nil) },
+	}
+)
+
+// AcquireReader возвращает FastReader с буфером по умолчанию из пула,
+// настроенный на чтение из r. Настройки такие же, как у NewReader(r)
+// без опций. После работы верните его через ReleaseReader.
+func AcquireReader(r io.Reader) *FastReader {
+	fr := readerPool.Get().(*FastReader)
+	fr.Reset(r)
+	return fr
+}
+
+// ReleaseReader возвращает fr в пул. После вызова fr и полученные
+// из него срезы (NextWordBytes и т. п.) использовать нельзя.
+// Ридеры с нестандартным размером буфера (WithBufferSize или буфер,
+// выросший из-за Mark) в пул не попадают.
+func ReleaseReader(fr *FastReader) {
+	if fr == nil || fr.mapped || len(fr.buf) != defaultReaderBufSize {
+		return
+	}
+	fr.Reset(nil)
+	fr.SetDelimiters(nil)
+	fr.unicode = false
+	fr.utf8Mode = InvalidUTF8Replace
+	fr.maxToken, fr.maxLine = 0, 0
+	fr.longToken = LongTokenDiscard
+	fr.ctx, fr.deadline = nil, nil
+	readerPool.Put(fr)
+}
+
+// AcquireWriter возвращает FastWriter с буфером по умолчанию из пула,
+// настроенный на запись в w, как NewWriter(w) без опций.
+// После Flush верните его через ReleaseWriter.
+func AcquireWriter(w io.Writer) *FastWriter {
+	fw := writerPool.Get().(*FastWriter)
+	fw.Reset(w)
+	return fw
+}
+
+// ReleaseWriter возвращает fw в пул. Writer с несброшенными данными
+// (ErrUnflushed) или с ошибкой записи (возвращается сама ошибка)
+// в пул не попадает, чтобы данные не потерялись незаметно.
+// Writer с нестандартным размером буфера просто отбрасывается.
+func ReleaseWriter(fw *FastWriter) error {
+	if fw == nil {
+		return nil
+	}
+	if fw.err != nil {
+		return fw.err
+	}
+	if fw.pos > 0 {
+		return ErrUnflushed
+	}
+	if len(fw.buf) != defaultWriterBufSize {
+		return nil
+	}
+	fw.Reset(nil)
+	fw.autoFlush = false
+	fw.limit = len(fw.buf) / 2
+	fw.ctx, fw.deadline = nil, nil
+	writerPool.Put(fw)
+	return nil
+}
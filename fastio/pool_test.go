@@ -0,0 +1,105 @@
+package fastio
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReaderReset(t *testing.T) {
+	r := NewReader(strings.NewReader("1\n2 3"), WithDelimiters(NewDelimiters(SpaceDelimiters+",")))
+	if _, err := r.NextLine(); err != nil {
+		t.Fatalf("NextLine error: %v", err)
+	}
+	r.Mark()
+	for r.Err() == nil {
+		_, _ = r.NextWord()
+	}
+
+	r.Reset(strings.NewReader("4,5\n6"))
+	for _, want := range []int{4, 5, 6} {
+		v, err := r.NextInt()
+		if err != nil || v != want {
+			t.Fatalf("NextInt after Reset = %d, %v; want %d", v, err, want)
+		}
+	}
+	if p := r.Position(); p.Offset != 5 || p.Line != 2 || p.Col != 2 {
+		t.Fatalf("Position after Reset = %+v; want offset 5 at 2:2", p)
+	}
+}
+
+func TestWriterReset(t *testing.T) {
+	var first, second bytes.Buffer
+	w := NewWriter(&first)
+	_ = w.WriteString("lost")
+	if w.Buffered() != 4 {
+		t.Fatalf("Buffered = %d; want 4", w.Buffered())
+	}
+	w.Reset(&second)
+	_ = w.WriteString("kept")
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush error: %v", err)
+	}
+	if first.Len() != 0 || second.String() != "kept" {
+		t.Fatalf("outputs = %q, %q; want \"\", \"kept\"", first.String(), second.String())
+	}
+}
+
+func TestReaderPool(t *testing.T) {
+	r := AcquireReader(strings.NewReader("a,b"))
+	r.SetDelimiters(NewDelimiters(","))
+	if w, _ := r.NextWord(); w != "a" {
+		t.Fatalf("NextWord = %q; want a", w)
+	}
+	ReleaseReader(r)
+
+	// Настройки предыдущего владельца не переходят к следующему.
+	r = AcquireReader(strings.NewReader("x,y z"))
+	defer ReleaseReader(r)
+	if w, _ := r.NextWord(); w != "x,y" {
+		t.Fatalf("NextWord from pooled reader = %q; want x,y", w)
+	}
+}
+
+func TestWriterPool(t *testing.T) {
+	var buf bytes.Buffer
+	w := AcquireWriter(&buf)
+	_ = w.WriteString("data")
+	if err := ReleaseWriter(w); !errors.Is(err, ErrUnflushed) {
+		t.Fatalf("ReleaseWriter with pending data: expected ErrUnflushed, got: %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush error: %v", err)
+	}
+	if err := ReleaseWriter(w); err != nil {
+		t.Fatalf("ReleaseWriter error: %v", err)
+	}
+	if buf.String() != "data" {
+		t.Fatalf("output = %q; want data", buf.String())
+	}
+
+	w = AcquireWriter(&errorWriter{})
+	_ = w.WriteString("x")
+	_ = w.Flush()
+	if err := ReleaseWriter(w); err == nil {
+		t.Fatalf("ReleaseWriter after write error: expected error")
+	}
+}
+
+func TestPoolNoAllocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("sync.Pool drops items at random under the race detector")
+	}
+	in := strings.NewReader("")
+	out := &bytes.Buffer{}
+	allocs := testing.AllocsPerRun(100, func() {
+		r := AcquireReader(in)
+		ReleaseReader(r)
+		w := AcquireWriter(out)
+		_ = ReleaseWriter(w)
+	})
+	if allocs != 0 {
+		t.Fatalf("Acquire/Release allocs = %v; want 0", allocs)
+	}
+}
@@ -5,9 +5,14 @@
 package fastio
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"io"
+	"math"
 	"strconv"
+	"time"
+	"unicode/utf8"
 )
 
 const defaultReaderBufSize = 64 * 1024 // 64KB
@@ -17,7 +22,13 @@ const defaultReaderBufSize = 64 * 1024 // 64KB
 // Он обеспечивает:
 //   - минимальное количество аллокаций;
 //   - методы для чтения примитивов: NextInt, NextInt64, NextUint64,
-//     NextFloat64, NextWord, NextLine;
+//     NextFloat64, NextFloat32, NextBool, NextWord, NextLine;
+//   - zero-copy варианты NextWordBytes и NextLineBytes;
+//   - настраиваемые разделители токенов (SetDelimiters) и режим
+//     пробельных символов Unicode (WithUnicodeSpaces);
+//   - чтение UTF-8: ReadRune, NextRune;
+//   - откат к контрольной точке (Mark, ResetToMark), UnreadByte и
+//     UnreadRune (io.ByteScanner, io.RuneScanner);
 //   - совместимость с любым io.Reader (stdin, файл, сокет);
 //   - ручное управление ошибками через Err().
 //
@@ -28,35 +39,202 @@ type FastReader struct {
 	pos int
 	n   int
 	err error
+
+	// off — абсолютное смещение buf[0] во входном потоке,
+	// lines и lineStart — число '\n' до buf[0] и смещение начала
+	// последней строки до buf[0]. Используются для Position().
+	off       int64
+	lines     int
+	lineStart int64
+
+	// tok — scratch-буфер для токенов, пересекающих границу buf.
+	tok []byte
+
+	// delims — таблица разделителей токенов (см. Delimiters).
+	delims Delimiters
+
+	// marked и mark — активная контрольная точка (см. Mark): начиная со
+	// смещения mark данные не вытесняются из buf при fill().
+	marked bool
+	mark   int64
+
+	// lastRead — размер последнего прочитанного ReadByte/ReadRune символа,
+	// lastEnd — смещение сразу после него. Используются UnreadByte/UnreadRune.
+	lastRead int
+	lastEnd  int64
+
+	// mapped — buf указывает на отображённый в память файл (см. OpenMmap)
+	// и не может использоваться как буфер для чтения.
+	mapped bool
+
+	// unicode включает пробельные символы Unicode в разделители,
+	// utf8Mode задаёт обработку некорректного UTF-8 (см. WithInvalidUTF8),
+	// valid — scratch-буфер для слов и строк с заменёнными байтами.
+	unicode  bool
+	utf8Mode InvalidUTF8Mode
+	valid    []byte
+
+	// maxToken и maxLine — ограничения длины слова и строки
+	// (0 — без ограничения), longToken — обработка остатка
+	// слишком длинного токена (см. WithMaxTokenSize).
+	maxToken  int
+	maxLine   int
+	longToken LongTokenMode
+
+	// ctx отменяет чтение из r (см. WithContext), deadline —
+	// SetReadDeadline ридера r, если он его поддерживает.
+	ctx      context.Context
+	deadline func(time.Time) error
 }
 
 // NewReader создает FastReader поверх существующего io.Reader.
-// Буфер создаётся размером 64 KB.
+// По умолчанию буфер создаётся размером 64 KB; размер можно
+// изменить опцией WithBufferSize.
 //
 // Используется для быстрого чтения из stdin, файла или сетевого потока.
-func NewReader(r io.Reader) *FastReader {
-	return &FastReader{
-		r:   r,
-		buf: make([]byte, defaultReaderBufSize),
-	}
+func NewReader(r io.Reader, opts ...Option) *FastReader {
+	o := newOptions(defaultReaderBufSize, opts)
+	return newReader(r, make([]byte, o.bufSize), o)
+}
+
+func newReader(r io.Reader, buf []byte, o options) *FastReader {
+	fr := &FastReader{
+		r:         r,
+		buf:       buf,
+		unicode:   o.unicodeSpaces,
+		utf8Mode:  o.invalidUTF8,
+		maxToken:  o.maxToken,
+		maxLine:   o.maxLine,
+		longToken: o.longToken,
+		ctx:       o.ctx,
+		deadline:  readDeadline(o.ctx, r),
+	}
+	fr.SetDelimiters(o.delims)
+	return fr
 }
 
 // Err возвращает первую возникшую ошибку (включая io.EOF).
-// Если ошибка уже произошла, дальнейшее чтение недоступно.
+// Если ошибка уже произошла, дальнейшее чтение недоступно. io.EOF
+// сообщается, только когда прочитан весь буфер: конец io.Reader,
+// встреченный при упреждающем чтении, или конец файла MmapReader,
+// известный с открытия, до этого остаются внутренним состоянием.
 func (fr *FastReader) Err() error {
+	if fr.err == io.EOF && fr.pos < fr.n {
+		return nil
+	}
 	return fr.err
 }
 
+// Reset переключает FastReader на чтение из r, сбрасывая позицию,
+// ошибку, счётчики строк и контрольные точки. Буфер и настройки
+// (разделители, режимы Unicode, контекст WithContext) сохраняются, поэтому один FastReader
+// можно переиспользовать без новых аллокаций.
+func (fr *FastReader) Reset(r io.Reader) {
+	if fr.mapped {
+		fr.buf = make([]byte, defaultReaderBufSize)
+		fr.mapped = false
+	}
+	fr.r = r
+	fr.deadline = readDeadline(fr.ctx, r)
+	fr.pos = 0
+	fr.n = 0
+	fr.err = nil
+	fr.off = 0
+	fr.lines = 0
+	fr.lineStart = 0
+	fr.marked = false
+	fr.mark = 0
+	fr.lastRead = 0
+	fr.lastEnd = 0
+}
+
+// Position описывает место во входном потоке.
+// Line и Col считаются с единицы, Col измеряется в байтах.
+type Position struct {
+	Offset int64
+	Line   int
+	Col    int
+}
+
+// Position возвращает позицию следующего непрочитанного байта.
+// Строки и столбцы вычисляются лениво, поэтому вызов не замедляет чтение.
+func (fr *FastReader) Position() Position {
+	consumed := fr.buf[:fr.pos]
+	line := fr.lines + 1
+	lineStart := fr.lineStart
+	if c := bytes.Count(consumed, []byte{'\n'}); c > 0 {
+		line += c
+		lineStart = fr.off + int64(bytes.LastIndexByte(consumed, '\n')) + 1
+	}
+	offset := fr.off + int64(fr.pos)
+	return Position{Offset: offset, Line: line, Col: int(offset-lineStart) + 1}
+}
+
+func (fr *FastReader) offset() int64 {
+	return fr.off + int64(fr.pos)
+}
+
+// parseError строит *ParseError для токена, начинающегося со смещения start.
+// Токен не должен содержать '\n', поэтому строка совпадает с текущей.
+func (fr *FastReader) parseError(fn string, start int64, token string, err error) *ParseError {
+	p := fr.Position()
+	return &ParseError{
+		Func:   fn,
+		Offset: start,
+		Line:   p.Line,
+		Col:    p.Col - int(p.Offset-start),
+		Token:  token,
+		Err:    err,
+	}
+}
+
+// peekToken возвращает начало следующего токена без продвижения позиции.
+// Смотрит только в уже прочитанный буфер; используется для диагностики.
+func (fr *FastReader) peekToken() string {
+	end := fr.pos
+	for end < fr.n && end-fr.pos < maxTokenPreview && !fr.delims[fr.buf[end]] {
+		end++
+	}
+	return string(fr.buf[fr.pos:end])
+}
+
+// fill дочитывает данные из r. Байты buf[:pos] вытесняются, кроме
+// последнего прочитанного символа (для UnreadByte/UnreadRune) и всего,
+// что начинается с активной контрольной точки; сохранённые байты
+// переносятся в начало buf, а при нехватке места buf растёт.
 func (fr *FastReader) fill() {
 	if fr.err != nil {
 		return
 	}
-	n, err := fr.r.Read(fr.buf)
+	drop := fr.pos - fr.lastRead
+	if drop < 0 {
+		drop = 0
+	}
+	if fr.marked {
+		if m := int(fr.mark - fr.off); m < drop {
+			drop = m
+		}
+	}
+	if drop > 0 {
+		seen := fr.buf[:drop]
+		if c := bytes.Count(seen, []byte{'\n'}); c > 0 {
+			fr.lines += c
+			fr.lineStart = fr.off + int64(bytes.LastIndexByte(seen, '\n')) + 1
+		}
+		fr.off += int64(drop)
+		fr.n = copy(fr.buf, fr.buf[drop:fr.n])
+		fr.pos -= drop
+	}
+	if fr.n == len(fr.buf) {
+		buf := make([]byte, 2*len(fr.buf))
+		copy(buf, fr.buf[:fr.n])
+		fr.buf = buf
+	}
+	n, err := fr.read(fr.buf[fr.n:])
 	if n < 0 {
 		n = 0
 	}
-	fr.n = n
-	fr.pos = 0
+	fr.n += n
 	if err != nil {
 		fr.err = err
 	}
@@ -73,13 +251,15 @@ func (fr *FastReader) ReadByte() (byte, error) {
 
 	b := fr.buf[fr.pos]
 	fr.pos++
+	fr.lastRead = 1
+	fr.lastEnd = fr.offset()
 
 	if fr.pos >= fr.n {
 		// Try to read ahead to determine the correct terminal error state.
 		if fr.err == nil {
 			fr.fill()
 		}
-		if fr.n == 0 {
+		if fr.pos >= fr.n {
 			if fr.err == nil {
 				fr.err = io.EOF
 			}
@@ -113,7 +293,7 @@ func (fr *FastReader) ensureData() error {
 
 	if fr.pos >= fr.n {
 		fr.fill()
-		if fr.n == 0 {
+		if fr.pos >= fr.n {
 			if fr.err == nil {
 				fr.err = io.EOF
 			}
@@ -124,236 +304,480 @@ func (fr *FastReader) ensureData() error {
 	return nil
 }
 
-// SkipSpaces пропускает пробельные символы: пробелы, \n, \r, \t.
+// SkipSpaces пропускает разделители: по умолчанию пробелы, \n, \r, \t,
+// набор меняется через SetDelimiters. С опцией WithUnicodeSpaces
+// пропускаются также пробельные символы Unicode (U+00A0, U+3000 и т. д.).
 // Используется перед парсингом чисел и слов.
 //
 // Если пробелы находятся в конце файла — возвращает io.EOF.
 func (fr *FastReader) SkipSpaces() error {
 	for {
-		b, err := fr.PeekByte()
-		if err != nil {
+		if err := fr.ensureData(); err != nil {
 			if errors.Is(err, io.EOF) {
 				return nil
 			}
 			return err
 		}
-		if b == ' ' || b == '\n' || b == '\r' || b == '\t' {
-			_, _ = fr.ReadByte()
-			continue
+		buf := fr.buf[:fr.n]
+		i := fr.pos
+		for i < len(buf) && fr.delims[buf[i]] {
+			i++
+		}
+		fr.pos = i
+		if i < len(buf) {
+			if !fr.unicode || buf[i] < utf8.RuneSelf || !fr.skipUnicodeSpace() {
+				return nil
+			}
 		}
-		return nil
 	}
 }
 
-// NextWord читает последовательность непробельных символов.
-// Используется для токенизации входа.
+// NextWord читает последовательность символов, не являющихся разделителями.
+// Используется для токенизации входа. Слово длиннее WithMaxTokenSize
+// возвращается как *ParseError с ErrTokenTooLong.
 //
 // В случае отсутствия данных возвращает io.EOF.
 func (fr *FastReader) NextWord() (string, error) {
+	b, err := fr.word("NextWord")
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// NextWordBytes работает как NextWord, но возвращает срез без копирования.
+//
+// Срез указывает во внутренний буфер FastReader и действителен только
+// до следующего вызова любого метода чтения. Если слово пересекает
+// границу буфера, оно собирается во внутреннем scratch-буфере,
+// который переиспользуется между вызовами.
+//
+// В случае отсутствия данных возвращает io.EOF.
+func (fr *FastReader) NextWordBytes() ([]byte, error) {
+	return fr.word("NextWordBytes")
+}
+
+// word читает слово для NextWord, NextWordBytes и строковых полей
+// Scan и Decode и обрабатывает некорректный UTF-8 по WithInvalidUTF8.
+func (fr *FastReader) word(fn string) ([]byte, error) {
+	b, ascii, err := fr.scanWord(fn)
+	if err != nil || ascii || utf8.Valid(b) {
+		return b, err
+	}
+	if fr.utf8Mode == InvalidUTF8Error {
+		// Слово заканчивается в текущей позиции.
+		return nil, fr.parseError(fn, fr.offset()-int64(len(b)), preview(b), ErrInvalidUTF8)
+	}
+	return fr.replaceInvalid(b), nil
+}
+
+// nextWordBytes — ядро NextWordBytes без проверки UTF-8;
+// используется также для чтения токенов чисел. fn нужен для
+// ошибки ErrTokenTooLong.
+func (fr *FastReader) nextWordBytes(fn string) ([]byte, error) {
+	b, _, err := fr.scanWord(fn)
+	return b, err
+}
+
+// scanWord читает слово как nextWordBytes. ascii сообщает, что слово
+// целиком лежало в буфере и состоит из ASCII: тогда word не проверяет
+// его UTF-8 повторно.
+func (fr *FastReader) scanWord(fn string) (b []byte, ascii bool, err error) {
 	if err := fr.SkipSpaces(); err != nil {
-		if errors.Is(err, io.EOF) {
-			return "", io.EOF
+		return nil, false, err
+	}
+	if err := fr.ensureData(); err != nil {
+		return nil, false, err
+	}
+	if fr.unicode {
+		b, err = fr.nextWordUnicode(fn)
+		return b, false, err
+	}
+
+	max := fr.maxToken
+	start := fr.pos
+	i := start
+	var hi byte
+	for i < fr.n {
+		c := fr.buf[i]
+		if fr.delims[c] {
+			break
 		}
-		return "", err
+		hi |= c
+		i++
+	}
+	if max > 0 && i-start > max {
+		return nil, false, fr.tokenTooLong(fn, fr.offset(), fr.buf[start:i], max, false)
+	}
+	if i < fr.n || fr.err != nil {
+		fr.pos = i
+		return fr.buf[start:i], hi < utf8.RuneSelf, nil
 	}
 
-	var buf []byte
+	// Слово дошло до конца буфера: сохраняем прочитанную часть
+	// до того, как fill() перезапишет buf.
+	tokStart := fr.offset()
+	fr.tok = append(fr.tok[:0], fr.buf[start:i]...)
+	fr.pos = i
 	for {
-		b, err := fr.PeekByte()
-		if err != nil {
+		if err := fr.ensureData(); err != nil {
 			if errors.Is(err, io.EOF) {
-				if len(buf) == 0 {
-					return "", io.EOF
-				}
-				return string(buf), nil
+				return fr.tok, false, nil
 			}
-			return "", err
+			return nil, false, err
 		}
-		if b == ' ' || b == '\n' || b == '\r' || b == '\t' {
-			break
+		start = fr.pos
+		i = start
+		for i < fr.n && !fr.delims[fr.buf[i]] {
+			i++
+		}
+		if max > 0 && len(fr.tok)+i-start > max {
+			return nil, false, fr.tokenTooLong(fn, tokStart, fr.tok, max, false)
+		}
+		fr.tok = append(fr.tok, fr.buf[start:i]...)
+		fr.pos = i
+		if i < fr.n {
+			return fr.tok, false, nil
 		}
-		_, _ = fr.ReadByte()
-		buf = append(buf, b)
-	}
-	if len(buf) == 0 {
-		return "", io.EOF
 	}
-	return string(buf), nil
 }
 
 // NextInt читает целое число типа int (со знаком).
 // Формат поддерживает ведущие пробелы, знак '+' или '-'.
 //
-// В случае отсутствия цифр возвращает ошибку.
+// В случае отсутствия цифр возвращает ErrNoDigits, при выходе
+// за границы int — ErrRange (число при этом дочитывается до конца).
 func (fr *FastReader) NextInt() (int, error) {
-	if err := fr.SkipSpaces(); err != nil {
+	val, neg, err := fr.nextInteger("NextInt", true, math.MaxInt)
+	if err != nil {
 		return 0, err
 	}
+	if neg {
+		return int(-val), nil
+	}
+	return int(val), nil
+}
 
-	sign := 1
-	b, err := fr.PeekByte()
+// NextInt64 читает 64-битное целое число со знаком.
+// Работает аналогично NextInt, но возвращает int64.
+// Допустимый диапазон — от -9223372036854775808 до 9223372036854775807.
+func (fr *FastReader) NextInt64() (int64, error) {
+	val, neg, err := fr.nextInteger("NextInt64", true, math.MaxInt64)
 	if err != nil {
 		return 0, err
 	}
-	if b == '-' {
-		sign = -1
-		_, _ = fr.ReadByte()
-	} else if b == '+' {
-		_, _ = fr.ReadByte()
+	if neg {
+		return int64(-val), nil
 	}
+	return int64(val), nil
+}
+
+// NextUint64 читает беззнаковое целое число.
+// Допускается ведущий '+' перед числом.
+//
+// В случае отсутствия цифр возвращает ErrNoDigits,
+// при значении больше 18446744073709551615 — ErrRange.
+func (fr *FastReader) NextUint64() (uint64, error) {
+	val, _, err := fr.nextInteger("NextUint64", false, math.MaxUint64)
+	return val, err
+}
 
-	var val int
-	digitsRead := 0
+// nextInteger — общее ядро NextInt, NextInt64 и NextUint64.
+//
+// Пропускает пробелы, разбирает необязательный знак ('-' только при signed)
+// и десятичные цифры. Цифры сканируются прямо по fr.buf[fr.pos:fr.n];
+// буфер дочитывается, только если число пересекает его границу.
+// Возвращает модуль числа, не превышающий max (max+1 для отрицательных),
+// и признак отрицательного знака.
+func (fr *FastReader) nextInteger(fn string, signed bool, max uint64) (uint64, bool, error) {
+	if err := fr.SkipSpaces(); err != nil {
+		return 0, false, err
+	}
+	if fr.pos >= fr.n {
+		if err := fr.ensureData(); err != nil {
+			return 0, false, err
+		}
+	}
+	start := fr.offset()
+
+	var sign byte
+	neg := false
+	limit := max
+	if b := fr.buf[fr.pos]; b == '+' || (b == '-' && signed) {
+		sign = b
+		fr.pos++
+		if b == '-' {
+			neg = true
+			limit++
+		}
+	}
 
+	cutoff, cutlim := limit/10, byte(limit%10)
+	var val uint64
+	digits := 0
 	for {
-		b, err = fr.PeekByte()
-		if err != nil {
-			if errors.Is(err, io.EOF) {
+		buf := fr.buf[:fr.n]
+		i := fr.pos
+		for i < len(buf) {
+			c := buf[i] - '0'
+			if c > 9 {
 				break
 			}
-			return 0, err
+			if val > cutoff || (val == cutoff && c > cutlim) {
+				fr.pos = i
+				return 0, false, fr.integerOverflow(fn, start, sign, val)
+			}
+			val = val*10 + uint64(c)
+			i++
 		}
-		if b < '0' || b > '9' {
+		digits += i - fr.pos
+		fr.pos = i
+		if fr.tokenExceeded(start) {
+			tok := strconv.AppendUint(nil, val, 10)
+			if sign != 0 {
+				tok = append([]byte{sign}, tok...)
+			}
+			return 0, false, fr.tokenTooLong(fn, start, tok, fr.maxToken, false)
+		}
+		if i < len(buf) {
 			break
 		}
-		_, _ = fr.ReadByte()
-		val = val*10 + int(b-'0')
-		digitsRead++
+		if err := fr.ensureData(); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return 0, false, err
+		}
 	}
 
-	if digitsRead == 0 {
-		return 0, errors.New("fastio: NextInt: no digits found")
+	if digits == 0 {
+		tok := fr.peekToken()
+		if sign != 0 {
+			tok = string(sign) + tok
+		}
+		return 0, false, fr.parseError(fn, start, tok, ErrNoDigits)
 	}
-	return sign * val, nil
+	return val, neg, nil
 }
 
-// NextInt64 читает 64-битное целое число со знаком.
-// Работает аналогично NextInt, но возвращает int64.
-func (fr *FastReader) NextInt64() (int64, error) {
-	if err := fr.SkipSpaces(); err != nil {
-		return 0, err
-	}
-
-	sign := int64(1)
-	b, err := fr.PeekByte()
-	if err != nil {
-		return 0, err
-	}
-	if b == '-' {
-		sign = -1
-		_, _ = fr.ReadByte()
-	} else if b == '+' {
-		_, _ = fr.ReadByte()
+// integerOverflow дочитывает оставшиеся цифры переполненного числа
+// и возвращает *ParseError с ErrRange. val — уже накопленный префикс.
+// В Token попадают только первые maxTokenPreview байт; число длиннее
+// WithMaxTokenSize возвращается с ErrTokenTooLong.
+func (fr *FastReader) integerOverflow(fn string, start int64, sign byte, val uint64) error {
+	var tok []byte
+	if sign != 0 {
+		tok = append(tok, sign)
 	}
-
-	var val int64
-	digitsRead := 0
-
+	tok = strconv.AppendUint(tok, val, 10)
 	for {
-		b, err = fr.PeekByte()
+		if fr.tokenExceeded(start) {
+			return fr.tokenTooLong(fn, start, tok, fr.maxToken, false)
+		}
+		b, err := fr.PeekByte()
 		if err != nil {
 			if errors.Is(err, io.EOF) {
 				break
 			}
-			return 0, err
+			return err
 		}
 		if b < '0' || b > '9' {
 			break
 		}
 		_, _ = fr.ReadByte()
-		val = val*10 + int64(b-'0')
-		digitsRead++
-	}
-	if digitsRead == 0 {
-		return 0, errors.New("fastio: NextInt64: no digits found")
+		if len(tok) < maxTokenPreview {
+			tok = append(tok, b)
+		}
 	}
-	return sign * val, nil
+	return fr.parseError(fn, start, string(tok), ErrRange)
 }
 
-// NextUint64 читает беззнаковое целое число.
-// Допускается ведущий '+' перед числом.
+// NextFloat64 читает число в формате float64.
+// Поддерживает: целые, дробные, экспоненциальные ("1e9") форматы,
+// а также "inf", "infinity" и "nan" без учёта регистра.
+// Разбор выполняется прямо по буферу без аллокаций; результат
+// совпадает со strconv.ParseFloat(token, 64).
 //
-// В случае отсутствия цифр возвращает ошибку.
-func (fr *FastReader) NextUint64() (uint64, error) {
+// Некорректный токен возвращается как *ParseError с ErrSyntax,
+// значение вне диапазона float64 — с ErrRange.
+func (fr *FastReader) NextFloat64() (float64, error) {
 	if err := fr.SkipSpaces(); err != nil {
 		return 0, err
 	}
+	start := fr.offset()
 
-	b, err := fr.PeekByte()
+	token, err := fr.nextWordBytes("NextFloat64")
 	if err != nil {
 		return 0, err
 	}
-
-	if b == '+' {
-		_, _ = fr.ReadByte()
+	v, err := atof64(token)
+	if err != nil {
+		return 0, fr.parseError("NextFloat64", start, string(token), err)
 	}
+	return v, nil
+}
 
-	var val uint64
-	digitsRead := 0
-
-	for {
-		b, err = fr.PeekByte()
-		if err != nil {
-			if errors.Is(err, io.EOF) {
-				break
-			}
-			return 0, err
-		}
-		if b < '0' || b > '9' {
-			break
-		}
-		_, _ = fr.ReadByte()
-		val = val*10 + uint64(b-'0')
-		digitsRead++
-	}
-	if digitsRead == 0 {
-		return 0, errors.New("fastio: NextUint64: no digits found")
+// NextFloat32 читает число в формате float32.
+// Работает аналогично NextFloat64, но округляет сразу до float32,
+// как strconv.ParseFloat(token, 32).
+func (fr *FastReader) NextFloat32() (float32, error) {
+	if err := fr.SkipSpaces(); err != nil {
+		return 0, err
 	}
-	return val, nil
-}
+	start := fr.offset()
 
-// NextFloat64 читает число в формате float64.
-// Поддерживает: целые, дробные, экспоненциальные ("1e9") форматы.
-// Реализовано через NextWord + strconv.ParseFloat.
-func (fr *FastReader) NextFloat64() (float64, error) {
-	token, err := fr.NextWord()
+	token, err := fr.nextWordBytes("NextFloat32")
 	if err != nil {
 		return 0, err
 	}
-	v, err := strconv.ParseFloat(token, 64)
+	v, err := atof32(token)
 	if err != nil {
-		return 0, err
+		return 0, fr.parseError("NextFloat32", start, string(token), err)
 	}
 	return v, nil
 }
 
+// NextBool читает логическое значение. Принимаются те же токены,
+// что и у strconv.ParseBool: "1", "t", "T", "true", "TRUE", "True"
+// и "0", "f", "F", "false", "FALSE", "False".
+//
+// Другой токен возвращается как *ParseError с ErrSyntax.
+func (fr *FastReader) NextBool() (bool, error) {
+	return fr.scanBool("NextBool")
+}
+
 // NextLine читает строку до символа '\n'.
 // Символ переноса строки не включается в результат.
 // CRLF ("\r\n") приводится к обычному LF.
+// Строка длиннее WithMaxLineSize возвращается как *ParseError
+// с ErrTokenTooLong.
 //
 // В случае пустого оставшегося ввода возвращает io.EOF.
 func (fr *FastReader) NextLine() (string, error) {
-	var buf []byte
+	b, err := fr.line("NextLine")
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// NextLineBytes работает как NextLine, но возвращает срез без копирования.
+//
+// Срез указывает во внутренний буфер FastReader и действителен только
+// до следующего вызова любого метода чтения. Если строка пересекает
+// границу буфера, она собирается во внутреннем scratch-буфере.
+//
+// В случае пустого оставшегося ввода возвращает io.EOF.
+func (fr *FastReader) NextLineBytes() ([]byte, error) {
+	return fr.line("NextLineBytes")
+}
+
+// line читает строку для NextLine и NextLineBytes и обрабатывает
+// некорректный UTF-8 по WithInvalidUTF8.
+func (fr *FastReader) line(fn string) ([]byte, error) {
+	b, err := fr.rawLine(fn)
+	if err != nil || utf8.Valid(b) {
+		return trimCR(b), err
+	}
+	if fr.utf8Mode == InvalidUTF8Error {
+		return nil, fr.lineError(fn, b, ErrInvalidUTF8)
+	}
+	return trimCR(fr.replaceInvalid(b)), nil
+}
 
+// lineError строит *ParseError для только что прочитанной строки raw
+// (без '\n', но с '\r' перед ним). Позиция уже стоит за '\n', поэтому
+// строка и столбец считаются от него.
+func (fr *FastReader) lineError(fn string, raw []byte, err error) *ParseError {
+	pos := fr.pos
+	if pos > 0 && fr.buf[pos-1] == '\n' {
+		fr.pos--
+	}
+	p := fr.Position()
+	fr.pos = pos
+	return &ParseError{
+		Func:   fn,
+		Offset: p.Offset - int64(len(raw)),
+		Line:   p.Line,
+		Col:    p.Col - len(raw),
+		Token:  preview(trimCR(raw)),
+		Err:    err,
+	}
+}
+
+// replaceInvalid возвращает копию b, в которой каждый байт некорректной
+// последовательности UTF-8 заменён на U+FFFD, как его возвращает ReadRune.
+func (fr *FastReader) replaceInvalid(b []byte) []byte {
+	fr.valid = fr.valid[:0]
+	for len(b) > 0 {
+		r, size := utf8.DecodeRune(b)
+		if r == utf8.RuneError && size == 1 {
+			fr.valid = utf8.AppendRune(fr.valid, utf8.RuneError)
+		} else {
+			fr.valid = append(fr.valid, b[:size]...)
+		}
+		b = b[size:]
+	}
+	return fr.valid
+}
+
+// rawLine читает строку с учётом WithMaxLineSize; '\r' перед '\n'
+// остаётся в результате.
+func (fr *FastReader) rawLine(fn string) ([]byte, error) {
+	if err := fr.ensureData(); err != nil {
+		return nil, err
+	}
+
+	max := fr.maxLine
+	start := fr.pos
+	if i := bytes.IndexByte(fr.buf[start:fr.n], '\n'); i >= 0 {
+		if max > 0 && i > max {
+			return nil, fr.tokenTooLong(fn, fr.offset(), fr.buf[start:start+i], max, true)
+		}
+		fr.pos = start + i + 1
+		return fr.buf[start : start+i], nil
+	}
+	if max > 0 && fr.n-start > max {
+		return nil, fr.tokenTooLong(fn, fr.offset(), fr.buf[start:fr.n], max, true)
+	}
+	if fr.err != nil {
+		fr.pos = fr.n
+		return fr.buf[start:fr.n], nil
+	}
+
+	// Строка дошла до конца буфера: сохраняем прочитанную часть
+	// до того, как fill() перезапишет buf.
+	lineStart := fr.offset()
+	fr.tok = append(fr.tok[:0], fr.buf[start:fr.n]...)
+	fr.pos = fr.n
 	for {
-		b, err := fr.ReadByte()
-		if err != nil {
+		if err := fr.ensureData(); err != nil {
 			if errors.Is(err, io.EOF) {
-				if len(buf) == 0 {
-					return "", io.EOF
-				}
-				break
+				return fr.tok, nil
 			}
-			return "", err
+			return nil, err
 		}
-		if b == '\n' {
-			break
+		start = fr.pos
+		end := fr.n
+		i := bytes.IndexByte(fr.buf[start:fr.n], '\n')
+		if i >= 0 {
+			end = start + i
+		}
+		if max > 0 && len(fr.tok)+end-start > max {
+			return nil, fr.tokenTooLong(fn, lineStart, fr.tok, max, true)
+		}
+		fr.tok = append(fr.tok, fr.buf[start:end]...)
+		fr.pos = end
+		if i >= 0 {
+			fr.pos++
+			return fr.tok, nil
 		}
-		buf = append(buf, b)
-	}
-	if len(buf) > 0 && buf[len(buf)-1] == '\r' {
-		buf = buf[:len(buf)-1]
 	}
+}
 
-	return string(buf), nil
+func trimCR(b []byte) []byte {
+	if len(b) > 0 && b[len(b)-1] == '\r' {
+		return b[:len(b)-1]
+	}
+	return b
 }
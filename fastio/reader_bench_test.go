@@ -4,6 +4,8 @@ import (
 	"bufio"
 	"bytes"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"testing"
@@ -65,6 +67,27 @@ func BenchmarkFmtFscan(b *testing.B) {
 	}
 }
 
+func BenchmarkFastReader_Scan(b *testing.B) {
+	data := makeIntInput(benchNumCount)
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		r := NewReader(bytes.NewReader(data))
+
+		sum := 0
+		var x int
+		for j := 0; j < benchNumCount; j++ {
+			if _, err := r.Scan(&x); err != nil {
+				b.Fatalf("Scan error: %v", err)
+			}
+			sum += x
+		}
+		_ = sum
+	}
+}
+
 func BenchmarkBufioScanner(b *testing.B) {
 	data := makeIntInput(benchNumCount)
 
@@ -98,3 +121,290 @@ func BenchmarkBufioScanner(b *testing.B) {
 		_ = sum
 	}
 }
+
+func makeWordInput(count int) []byte {
+	var sb strings.Builder
+	for i := 0; i < count; i++ {
+		if i%10 == 9 {
+			sb.WriteByte('\n')
+		} else if i > 0 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString("word")
+		sb.WriteString(strconv.Itoa(i))
+	}
+	sb.WriteByte('\n')
+	return []byte(sb.String())
+}
+
+func BenchmarkFastReader_NextWord(b *testing.B) {
+	data := makeWordInput(benchNumCount)
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		r := NewReader(bytes.NewReader(data))
+
+		total := 0
+		for j := 0; j < benchNumCount; j++ {
+			w, err := r.NextWord()
+			if err != nil {
+				b.Fatalf("NextWord error: %v", err)
+			}
+			total += len(w)
+		}
+		_ = total
+	}
+}
+
+func BenchmarkFastReader_NextWordBytes(b *testing.B) {
+	data := makeWordInput(benchNumCount)
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		r := NewReader(bytes.NewReader(data))
+
+		total := 0
+		for j := 0; j < benchNumCount; j++ {
+			w, err := r.NextWordBytes()
+			if err != nil {
+				b.Fatalf("NextWordBytes error: %v", err)
+			}
+			total += len(w)
+		}
+		_ = total
+	}
+}
+
+func makeSignedIntInput(count int) []byte {
+	var sb strings.Builder
+	for i := 0; i < count; i++ {
+		if i > 0 {
+			sb.WriteByte(' ')
+		}
+		v := int64(i) * 1_000_003_717
+		if i%2 == 1 {
+			v = -v
+		}
+		sb.WriteString(strconv.FormatInt(v, 10))
+	}
+	sb.WriteByte('\n')
+	return []byte(sb.String())
+}
+
+func BenchmarkFastReader_NextInt64(b *testing.B) {
+	data := makeSignedIntInput(benchNumCount)
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		r := NewReader(bytes.NewReader(data))
+
+		var sum int64
+		for j := 0; j < benchNumCount; j++ {
+			x, err := r.NextInt64()
+			if err != nil {
+				b.Fatalf("NextInt64 error: %v", err)
+			}
+			sum += x
+		}
+		_ = sum
+	}
+}
+
+func BenchmarkBufioScanner_Int64(b *testing.B) {
+	data := makeSignedIntInput(benchNumCount)
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		sc := bufio.NewScanner(bytes.NewReader(data))
+		sc.Split(bufio.ScanWords)
+
+		var sum int64
+		read := 0
+		for read < benchNumCount && sc.Scan() {
+			x, err := strconv.ParseInt(sc.Text(), 10, 64)
+			if err != nil {
+				b.Fatalf("ParseInt error: %v", err)
+			}
+			sum += x
+			read++
+		}
+		if read != benchNumCount {
+			b.Fatalf("scanner read %d numbers, expected %d", read, benchNumCount)
+		}
+		_ = sum
+	}
+}
+
+func makeFloatInput(count int) []byte {
+	var sb strings.Builder
+	for i := 0; i < count; i++ {
+		if i > 0 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(strconv.FormatFloat(float64(i)*0.017453292519943295-90, 'f', 6, 64))
+	}
+	sb.WriteByte('\n')
+	return []byte(sb.String())
+}
+
+func BenchmarkFastReader_NextFloat64(b *testing.B) {
+	data := makeFloatInput(benchNumCount)
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		r := NewReader(bytes.NewReader(data))
+
+		sum := 0.0
+		for j := 0; j < benchNumCount; j++ {
+			x, err := r.NextFloat64()
+			if err != nil {
+				b.Fatalf("NextFloat64 error: %v", err)
+			}
+			sum += x
+		}
+		_ = sum
+	}
+}
+
+func BenchmarkBufioScanner_ParseFloat(b *testing.B) {
+	data := makeFloatInput(benchNumCount)
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		sc := bufio.NewScanner(bytes.NewReader(data))
+		sc.Split(bufio.ScanWords)
+
+		sum := 0.0
+		read := 0
+		for read < benchNumCount && sc.Scan() {
+			x, err := strconv.ParseFloat(sc.Text(), 64)
+			if err != nil {
+				b.Fatalf("ParseFloat error: %v", err)
+			}
+			sum += x
+			read++
+		}
+		if read != benchNumCount {
+			b.Fatalf("scanner read %d numbers, expected %d", read, benchNumCount)
+		}
+		_ = sum
+	}
+}
+
+func BenchmarkMmapReader_NextInt(b *testing.B) {
+	const count = 1000000
+	path := filepath.Join(b.TempDir(), "ints.txt")
+	if err := os.WriteFile(path, makeIntInput(count), 0o644); err != nil {
+		b.Fatalf("WriteFile error: %v", err)
+	}
+
+	b.Run("Mmap", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			mr, err := OpenMmap(path)
+			if err != nil {
+				b.Fatalf("OpenMmap error: %v", err)
+			}
+			for j := 0; j < count; j++ {
+				if _, err := mr.NextInt(); err != nil {
+					b.Fatalf("NextInt error: %v", err)
+				}
+			}
+			_ = mr.Close()
+		}
+	})
+	b.Run("File", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			f, err := os.Open(path)
+			if err != nil {
+				b.Fatalf("Open error: %v", err)
+			}
+			r := NewReader(f)
+			for j := 0; j < count; j++ {
+				if _, err := r.NextInt(); err != nil {
+					b.Fatalf("NextInt error: %v", err)
+				}
+			}
+			_ = f.Close()
+		}
+	})
+}
+
+type benchRecord struct {
+	ID     int
+	Name   string
+	Score  int64
+	Active bool
+}
+
+func makeRecordInput(count int) []byte {
+	var sb strings.Builder
+	for i := 0; i < count; i++ {
+		sb.WriteString(strconv.Itoa(i))
+		sb.WriteString(" user")
+		sb.WriteString(strconv.Itoa(i % 100))
+		sb.WriteByte(' ')
+		sb.WriteString(strconv.Itoa(i * 7))
+		sb.WriteString(" 1\n")
+	}
+	return []byte(sb.String())
+}
+
+func BenchmarkFastReader_Decode(b *testing.B) {
+	data := makeRecordInput(benchNumCount)
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		r := NewReader(bytes.NewReader(data))
+
+		var rec benchRecord
+		for j := 0; j < benchNumCount; j++ {
+			if err := r.Decode(&rec); err != nil {
+				b.Fatalf("Decode error: %v", err)
+			}
+		}
+	}
+}
+
+func BenchmarkFastReader_DecodeManual(b *testing.B) {
+	data := makeRecordInput(benchNumCount)
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		r := NewReader(bytes.NewReader(data))
+
+		var rec benchRecord
+		for j := 0; j < benchNumCount; j++ {
+			var err error
+			if rec.ID, err = r.NextInt(); err != nil {
+				b.Fatalf("NextInt error: %v", err)
+			}
+			if rec.Name, err = r.NextWord(); err != nil {
+				b.Fatalf("NextWord error: %v", err)
+			}
+			if rec.Score, err = r.NextInt64(); err != nil {
+				b.Fatalf("NextInt64 error: %v", err)
+			}
+			if _, err = r.Scan(&rec.Active); err != nil {
+				b.Fatalf("Scan error: %v", err)
+			}
+		}
+	}
+}
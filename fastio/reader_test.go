@@ -1,10 +1,14 @@
 package fastio
 
 import (
+	"bytes"
 	"errors"
 	"io"
+	"math"
+	"strconv"
 	"strings"
 	"testing"
+	"testing/iotest"
 )
 
 func newTestReader(s string) *FastReader {
@@ -244,3 +248,335 @@ func TestNextFloat64(t *testing.T) {
 		t.Fatalf("NextFloat64 #3 expected 1000, got %v", v3)
 	}
 }
+
+func TestNextBool(t *testing.T) {
+	r := newTestReader("1 true F False yes")
+	for _, want := range []bool{true, true, false, false} {
+		if v, err := r.NextBool(); err != nil || v != want {
+			t.Fatalf("NextBool = %v, %v; want %v", v, err, want)
+		}
+	}
+	var pe *ParseError
+	if _, err := r.NextBool(); !errors.As(err, &pe) || !errors.Is(err, ErrSyntax) || pe.Func != "NextBool" {
+		t.Fatalf("Expected ParseError with ErrSyntax, got: %v", err)
+	}
+}
+
+func TestNextIntNoDigitsIsErrNoDigits(t *testing.T) {
+	r := newTestReader("-x")
+	_, err := r.NextInt()
+	if !errors.Is(err, ErrNoDigits) {
+		t.Fatalf("Expected ErrNoDigits, got: %v", err)
+	}
+	if err.Error() != `fastio: NextInt: 1:1: "-x": no digits found` {
+		t.Fatalf("Unexpected error message: %q", err.Error())
+	}
+}
+
+func TestNextInt64Overflow(t *testing.T) {
+	r := newTestReader("9223372036854775808 -9223372036854775809 99999999999999999999 7")
+	for i := 0; i < 3; i++ {
+		_, err := r.NextInt64()
+		if !errors.Is(err, ErrRange) {
+			t.Fatalf("NextInt64 #%d: expected ErrRange, got: %v", i, err)
+		}
+	}
+
+	// Число с переполнением дочитывается целиком, следующее читается корректно.
+	v, err := r.NextInt64()
+	if err != nil {
+		t.Fatalf("NextInt64 after overflow error: %v", err)
+	}
+	if v != 7 {
+		t.Fatalf("NextInt64 after overflow = %d; want 7", v)
+	}
+}
+
+func TestNextIntOverflow(t *testing.T) {
+	r := newTestReader(strconv.FormatInt(math.MinInt, 10) + " " + strconv.FormatInt(math.MaxInt, 10) + " " + strconv.FormatInt(math.MaxInt, 10) + "0")
+
+	v1, err := r.NextInt()
+	if err != nil {
+		t.Fatalf("NextInt #1 error: %v", err)
+	}
+	if v1 != math.MinInt {
+		t.Fatalf("NextInt #1 = %d; want %d", v1, math.MinInt)
+	}
+
+	v2, err := r.NextInt()
+	if err != nil {
+		t.Fatalf("NextInt #2 error: %v", err)
+	}
+	if v2 != math.MaxInt {
+		t.Fatalf("NextInt #2 = %d; want %d", v2, math.MaxInt)
+	}
+
+	_, err = r.NextInt()
+	if !errors.Is(err, ErrRange) {
+		t.Fatalf("NextInt #3: expected ErrRange, got: %v", err)
+	}
+}
+
+func TestNextUint64Overflow(t *testing.T) {
+	r := newTestReader("18446744073709551616 184467440737095516150")
+	for i := 0; i < 2; i++ {
+		_, err := r.NextUint64()
+		if !errors.Is(err, ErrRange) {
+			t.Fatalf("NextUint64 #%d: expected ErrRange, got: %v", i, err)
+		}
+	}
+}
+
+func TestPosition(t *testing.T) {
+	r := NewReader(iotest.OneByteReader(strings.NewReader("ab\ncd\r\nef")))
+	want := []Position{
+		{Offset: 0, Line: 1, Col: 1},
+		{Offset: 3, Line: 2, Col: 1},
+		{Offset: 7, Line: 3, Col: 1},
+		{Offset: 9, Line: 3, Col: 3},
+	}
+
+	for i, w := range want {
+		if got := r.Position(); got != w {
+			t.Fatalf("Position #%d = %+v; want %+v", i, got, w)
+		}
+		_, _ = r.NextLine()
+	}
+}
+
+func TestParseErrorPosition(t *testing.T) {
+	r := newTestReader("1 2\n  3 x4\n99999999999999999999")
+	for i := 0; i < 3; i++ {
+		if _, err := r.NextInt64(); err != nil {
+			t.Fatalf("NextInt64 #%d error: %v", i, err)
+		}
+	}
+
+	_, err := r.NextInt64()
+	var pe *ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("Expected *ParseError, got: %v", err)
+	}
+	want := ParseError{Func: "NextInt64", Offset: 8, Line: 2, Col: 5, Token: "x4", Err: ErrNoDigits}
+	if *pe != want {
+		t.Fatalf("ParseError = %+v; want %+v", *pe, want)
+	}
+
+	_, _ = r.NextWord()
+	_, err = r.NextInt64()
+	if !errors.As(err, &pe) {
+		t.Fatalf("Expected *ParseError, got: %v", err)
+	}
+	want = ParseError{Func: "NextInt64", Offset: 11, Line: 3, Col: 1, Token: "99999999999999999999", Err: ErrRange}
+	if *pe != want {
+		t.Fatalf("ParseError = %+v; want %+v", *pe, want)
+	}
+}
+
+func TestNextFloat64ParseError(t *testing.T) {
+	r := newTestReader("1.5\n 1.2.3 1e999")
+	if _, err := r.NextFloat64(); err != nil {
+		t.Fatalf("NextFloat64 error: %v", err)
+	}
+
+	_, err := r.NextFloat64()
+	if !errors.Is(err, ErrSyntax) {
+		t.Fatalf("Expected ErrSyntax, got: %v", err)
+	}
+	if err.Error() != `fastio: NextFloat64: 2:2: "1.2.3": invalid syntax` {
+		t.Fatalf("Unexpected error message: %q", err.Error())
+	}
+
+	_, err = r.NextFloat64()
+	if !errors.Is(err, ErrRange) {
+		t.Fatalf("Expected ErrRange, got: %v", err)
+	}
+}
+
+func TestNextWordBytesAcrossFills(t *testing.T) {
+	for _, r := range []*FastReader{
+		newTestReader("alpha  beta\tgamma\r\ndelta"),
+		NewReader(iotest.OneByteReader(strings.NewReader("alpha  beta\tgamma\r\ndelta"))),
+		NewReader(iotest.DataErrReader(strings.NewReader("alpha  beta\tgamma\r\ndelta"))),
+	} {
+		want := []string{"alpha", "beta", "gamma", "delta"}
+		for i, w := range want {
+			v, err := r.NextWordBytes()
+			if err != nil {
+				t.Fatalf("NextWordBytes error at index %d: %v", i, err)
+			}
+			if string(v) != w {
+				t.Fatalf("NextWordBytes at index %d = %q; want %q", i, v, w)
+			}
+		}
+
+		_, err := r.NextWordBytes()
+		if !errors.Is(err, io.EOF) {
+			t.Fatalf("Expected EOF error, got: %v", err)
+		}
+	}
+}
+
+func TestNextLineBytesAcrossFills(t *testing.T) {
+	for _, r := range []*FastReader{
+		newTestReader("first\r\n\nthird line\nlast"),
+		NewReader(iotest.OneByteReader(strings.NewReader("first\r\n\nthird line\nlast"))),
+		NewReader(iotest.DataErrReader(strings.NewReader("first\r\n\nthird line\nlast"))),
+	} {
+		want := []string{"first", "", "third line", "last"}
+		for i, w := range want {
+			v, err := r.NextLineBytes()
+			if err != nil {
+				t.Fatalf("NextLineBytes error at index %d: %v", i, err)
+			}
+			if string(v) != w {
+				t.Fatalf("NextLineBytes at index %d = %q; want %q", i, v, w)
+			}
+		}
+
+		_, err := r.NextLineBytes()
+		if !errors.Is(err, io.EOF) {
+			t.Fatalf("Expected EOF error, got: %v", err)
+		}
+	}
+}
+
+func TestNextWordBytesNoAllocs(t *testing.T) {
+	data := []byte(strings.Repeat("word ", 1000))
+	r := NewReader(bytes.NewReader(data))
+	allocs := testing.AllocsPerRun(500, func() {
+		if _, err := r.NextWordBytes(); err != nil {
+			t.Fatalf("NextWordBytes error: %v", err)
+		}
+	})
+	if allocs != 0 {
+		t.Fatalf("NextWordBytes allocs = %v; want 0", allocs)
+	}
+}
+
+func TestNextIntegersAcrossFills(t *testing.T) {
+	input := "12345 -9223372036854775808 +18446744073709551615 -7 9223372036854775808"
+	r := NewReader(iotest.OneByteReader(strings.NewReader(input)))
+
+	v1, err := r.NextInt()
+	if err != nil || v1 != 12345 {
+		t.Fatalf("NextInt = %d, %v; want 12345", v1, err)
+	}
+	v2, err := r.NextInt64()
+	if err != nil || v2 != math.MinInt64 {
+		t.Fatalf("NextInt64 = %d, %v; want %d", v2, err, int64(math.MinInt64))
+	}
+	v3, err := r.NextUint64()
+	if err != nil || v3 != math.MaxUint64 {
+		t.Fatalf("NextUint64 = %d, %v; want %d", v3, err, uint64(math.MaxUint64))
+	}
+	_, err = r.NextUint64()
+	if !errors.Is(err, ErrNoDigits) {
+		t.Fatalf("NextUint64 on negative: expected ErrNoDigits, got: %v", err)
+	}
+	_, _ = r.ReadByte()
+	v4, err := r.NextInt()
+	if err != nil || v4 != 7 {
+		t.Fatalf("NextInt = %d, %v; want 7", v4, err)
+	}
+	_, err = r.NextInt64()
+	if !errors.Is(err, ErrRange) {
+		t.Fatalf("NextInt64: expected ErrRange, got: %v", err)
+	}
+	_, err = r.NextInt64()
+	if !errors.Is(err, io.EOF) {
+		t.Fatalf("Expected EOF error, got: %v", err)
+	}
+}
+
+func TestNextIntSignAtEOF(t *testing.T) {
+	r := newTestReader("  -")
+	_, err := r.NextInt()
+	if !errors.Is(err, ErrNoDigits) {
+		t.Fatalf("Expected ErrNoDigits, got: %v", err)
+	}
+}
+
+func TestReaderWithSmallBuffer(t *testing.T) {
+	input := "12345678901234 word-longer-than-buffer 2.718281828459045\nthe rest of the line\n-42"
+	r := NewReader(strings.NewReader(input), WithBufferSize(1))
+	if len(r.buf) != minBufSize {
+		t.Fatalf("buffer size = %d; want %d", len(r.buf), minBufSize)
+	}
+
+	v, err := r.NextInt64()
+	if err != nil || v != 12345678901234 {
+		t.Fatalf("NextInt64 = %d, %v; want 12345678901234", v, err)
+	}
+	w, err := r.NextWord()
+	if err != nil || w != "word-longer-than-buffer" {
+		t.Fatalf("NextWord = %q, %v; want %q", w, err, "word-longer-than-buffer")
+	}
+	f, err := r.NextFloat64()
+	if err != nil || f != 2.718281828459045 {
+		t.Fatalf("NextFloat64 = %v, %v; want 2.718281828459045", f, err)
+	}
+	_, _ = r.NextLine()
+	l, err := r.NextLine()
+	if err != nil || l != "the rest of the line" {
+		t.Fatalf("NextLine = %q, %v; want %q", l, err, "the rest of the line")
+	}
+	x, err := r.NextInt()
+	if err != nil || x != -42 {
+		t.Fatalf("NextInt = %d, %v; want -42", x, err)
+	}
+}
+
+func TestReaderWithBufferSize(t *testing.T) {
+	r := NewReader(strings.NewReader(""), WithBufferSize(4<<20))
+	if len(r.buf) != 4<<20 {
+		t.Fatalf("buffer size = %d; want %d", len(r.buf), 4<<20)
+	}
+	r = NewReader(strings.NewReader(""), WithBufferSize(0))
+	if len(r.buf) != defaultReaderBufSize {
+		t.Fatalf("buffer size = %d; want %d", len(r.buf), defaultReaderBufSize)
+	}
+}
+
+func TestCustomDelimiters(t *testing.T) {
+	r := newTestReader("1,2;3|4\n5,,6\v7\f8")
+	r.SetDelimiters(NewDelimiters(SpaceDelimiters + ",;|\v\f"))
+
+	for i := 1; i <= 8; i++ {
+		v, err := r.NextInt()
+		if err != nil {
+			t.Fatalf("NextInt error at index %d: %v", i, err)
+		}
+		if v != i {
+			t.Fatalf("NextInt at index %d = %d; want %d", i, v, i)
+		}
+	}
+	_, err := r.NextInt()
+	if !errors.Is(err, io.EOF) {
+		t.Fatalf("Expected EOF error, got: %v", err)
+	}
+}
+
+func TestDelimitersOptionAndWords(t *testing.T) {
+	d := NewDelimiters(";")
+	r := NewReader(iotest.OneByteReader(strings.NewReader("hello world;1.5;;x")), WithDelimiters(d))
+	d[' '] = true // таблица копируется, ридер не должен измениться
+
+	w, err := r.NextWord()
+	if err != nil || w != "hello world" {
+		t.Fatalf("NextWord = %q, %v; want %q", w, err, "hello world")
+	}
+	f, err := r.NextFloat64()
+	if err != nil || f != 1.5 {
+		t.Fatalf("NextFloat64 = %v, %v; want 1.5", f, err)
+	}
+	w, err = r.NextWord()
+	if err != nil || w != "x" {
+		t.Fatalf("NextWord = %q, %v; want %q", w, err, "x")
+	}
+
+	r.SetDelimiters(nil)
+	if !r.delims[' '] || r.delims[';'] {
+		t.Fatalf("SetDelimiters(nil) did not restore default delimiters")
+	}
+}
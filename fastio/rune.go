@@ -0,0 +1,186 @@
+package fastio
+
+import (
+	"errors"
+	"io"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ErrInvalidUTF8 возвращается при InvalidUTF8Error для некорректной
+// последовательности UTF-8.
+var ErrInvalidUTF8 = errors.New("invalid UTF-8")
+
+// InvalidUTF8Mode задаёт обработку некорректного UTF-8, см. WithInvalidUTF8.
+type InvalidUTF8Mode int
+
+// Режим действует на все методы, возвращающие текст: ReadRune, NextRune,
+// NextWord, NextWordBytes, NextLine, NextLineBytes, а также строки
+// и []byte, которые заполняют Scan и Decode. Числа, NextGrid и CSVReader
+// читают байты как есть.
+const (
+	// InvalidUTF8Replace заменяет каждый байт некорректной
+	// последовательности на utf8.RuneError: ReadRune и NextRune возвращают
+	// его размером 1, а в словах и строках он записывается как "\uFFFD".
+	// Слово или строка с заменой копируется во внутренний scratch-буфер,
+	// корректный текст возвращается без копирования.
+	InvalidUTF8Replace InvalidUTF8Mode = iota
+	// InvalidUTF8Error возвращает *ParseError с ErrInvalidUTF8.
+	// Некорректный байт, слово или строка при этом считаются прочитанными.
+	InvalidUTF8Error
+)
+
+// WithUnicodeSpaces включает режим, в котором SkipSpaces и все методы,
+// читающие токены, кроме разделителей из таблицы (см. WithDelimiters)
+// пропускают пробельные символы Unicode вне ASCII: U+0085, U+00A0,
+// U+2000–U+200A, U+3000 и другие, для которых unicode.IsSpace истинно.
+// На FastWriter опция не влияет.
+func WithUnicodeSpaces() Option {
+	return func(o *options) {
+		o.unicodeSpaces = true
+	}
+}
+
+// WithInvalidUTF8 задаёт обработку некорректного UTF-8 в FastReader;
+// по умолчанию InvalidUTF8Replace. На FastWriter опция не влияет.
+func WithInvalidUTF8(mode InvalidUTF8Mode) Option {
+	return func(o *options) {
+		o.invalidUTF8 = mode
+	}
+}
+
+// ReadRune читает один символ UTF-8 и возвращает его вместе с размером
+// в байтах, так что FastReader удовлетворяет io.RuneScanner. Руна,
+// пересекающая границу буфера, собирается дочитыванием. Некорректная
+// последовательность возвращается как utf8.RuneError размером 1
+// или, при InvalidUTF8Error, как *ParseError с ErrInvalidUTF8.
+//
+// В случае отсутствия данных возвращает io.EOF.
+func (fr *FastReader) ReadRune() (rune, int, error) {
+	return fr.readRune("ReadRune")
+}
+
+// NextRune пропускает разделители и читает следующий символ UTF-8
+// по правилам ReadRune.
+//
+// В случае отсутствия данных возвращает io.EOF.
+func (fr *FastReader) NextRune() (rune, error) {
+	if err := fr.SkipSpaces(); err != nil {
+		return 0, err
+	}
+	r, _, err := fr.readRune("NextRune")
+	return r, err
+}
+
+func (fr *FastReader) readRune(fn string) (rune, int, error) {
+	if err := fr.ensureData(); err != nil {
+		return 0, 0, err
+	}
+	fr.ensureRune()
+
+	r, size := rune(fr.buf[fr.pos]), 1
+	if r >= utf8.RuneSelf {
+		r, size = utf8.DecodeRune(fr.buf[fr.pos:fr.n])
+	}
+	fr.pos += size
+	fr.lastRead = size
+	fr.lastEnd = fr.offset()
+	if r == utf8.RuneError && size == 1 && fr.utf8Mode == InvalidUTF8Error {
+		return r, size, fr.parseError(fn, fr.offset()-1, string(fr.buf[fr.pos-1:fr.pos]), ErrInvalidUTF8)
+	}
+	return r, size, nil
+}
+
+// ensureRune дочитывает данные, пока руна в fr.buf[fr.pos:] не станет
+// полной или вход не закончится. fill() сохраняет непрочитанные байты.
+func (fr *FastReader) ensureRune() {
+	for fr.n-fr.pos < utf8.UTFMax && !utf8.FullRune(fr.buf[fr.pos:fr.n]) && fr.err == nil {
+		fr.fill()
+	}
+}
+
+// UnreadRune отменяет последний ReadRune. Допустим только сразу после
+// ReadRune или ReadByte; иначе возвращается ErrInvalidUnread.
+func (fr *FastReader) UnreadRune() error {
+	if fr.lastRead == 0 || fr.lastEnd != fr.offset() || fr.pos < fr.lastRead {
+		return ErrInvalidUnread
+	}
+	fr.pos -= fr.lastRead
+	fr.lastRead = 0
+	return nil
+}
+
+// skipUnicodeSpace пропускает пробельный символ Unicode в позиции fr.pos
+// (первый байт >= utf8.RuneSelf) и сообщает, был ли он пропущен.
+func (fr *FastReader) skipUnicodeSpace() bool {
+	fr.ensureRune()
+	r, size := utf8.DecodeRune(fr.buf[fr.pos:fr.n])
+	if !unicode.IsSpace(r) {
+		return false
+	}
+	fr.pos += size
+	return true
+}
+
+// scanWordUnicode ищет конец слова, начиная с fr.buf[i], с учётом
+// пробельных символов Unicode. done сообщает, что найден разделитель;
+// иначе слово может продолжаться за концом буфера.
+func (fr *FastReader) scanWordUnicode(i int) (end int, done bool) {
+	buf := fr.buf[:fr.n]
+	for i < len(buf) {
+		b := buf[i]
+		if b < utf8.RuneSelf {
+			if fr.delims[b] {
+				return i, true
+			}
+			i++
+			continue
+		}
+		if !utf8.FullRune(buf[i:]) {
+			if fr.err != nil {
+				return len(buf), false
+			}
+			return i, false
+		}
+		r, size := utf8.DecodeRune(buf[i:])
+		if unicode.IsSpace(r) {
+			return i, true
+		}
+		i += size
+	}
+	return i, false
+}
+
+// nextWordUnicode — вариант nextWordBytes для WithUnicodeSpaces.
+// Вместо сборки слова в fr.tok на время чтения ставится контрольная
+// точка в его начале: fill() сохраняет слово в buf целиком, включая
+// руны, разрезанные границей буфера.
+func (fr *FastReader) nextWordUnicode(fn string) ([]byte, error) {
+	start := fr.offset()
+	marked, mark := fr.marked, fr.mark
+	if !marked || start < mark {
+		fr.marked, fr.mark = true, start
+	}
+
+	max := fr.maxToken
+	tooLong := func(end int) bool {
+		return max > 0 && end-int(start-fr.off) > max
+	}
+	end, done := fr.scanWordUnicode(fr.pos)
+	for !done && fr.err == nil && !tooLong(end) {
+		fr.pos = end
+		fr.fill()
+		end, done = fr.scanWordUnicode(fr.pos)
+	}
+	fr.marked, fr.mark = marked, mark
+	if tooLong(end) {
+		fr.pos = end
+		return nil, fr.tokenTooLong(fn, start, fr.buf[start-fr.off:end], max, false)
+	}
+	if !done && !errors.Is(fr.err, io.EOF) {
+		fr.pos = end
+		return nil, fr.err
+	}
+	fr.pos = end
+	return fr.buf[start-fr.off : end], nil
+}
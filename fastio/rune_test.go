@@ -0,0 +1,158 @@
+package fastio
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"unicode/utf8"
+)
+
+func TestUnicodeSpaces(t *testing.T) {
+	in := "　a bж　cé 12  34\u0085 "
+	for _, r := range []*FastReader{
+		NewReader(strings.NewReader(in), WithUnicodeSpaces()),
+		NewReader(iotest.OneByteReader(strings.NewReader(in)), WithUnicodeSpaces(), WithBufferSize(16)),
+	} {
+		for _, want := range []string{"a", "bж", "cé"} {
+			w, err := r.NextWord()
+			if err != nil || w != want {
+				t.Fatalf("NextWord = %q, %v; want %q", w, err, want)
+			}
+		}
+		for _, want := range []int{12, 34} {
+			v, err := r.NextInt()
+			if err != nil || v != want {
+				t.Fatalf("NextInt = %d, %v; want %d", v, err, want)
+			}
+		}
+		if _, err := r.NextWord(); !errors.Is(err, io.EOF) {
+			t.Fatalf("Expected EOF error, got: %v", err)
+		}
+	}
+
+	// Без опции U+00A0 остаётся частью слова.
+	r := newTestReader("a b c")
+	if w, _ := r.NextWord(); w != "a b" {
+		t.Fatalf("NextWord without unicode spaces = %q; want %q", w, "a b")
+	}
+}
+
+func TestUnicodeSpacesLongWordAcrossFills(t *testing.T) {
+	long := strings.Repeat("жы", 50)
+	in := long + "　" + long
+	r := NewReader(iotest.OneByteReader(strings.NewReader(in)), WithUnicodeSpaces(), WithBufferSize(16))
+	for i := 0; i < 2; i++ {
+		w, err := r.NextWordBytes()
+		if err != nil || string(w) != long {
+			t.Fatalf("NextWordBytes = %q, %v; want %q", w, err, long)
+		}
+	}
+	if p := r.Position(); p.Offset != int64(len(in)) || p.Line != 1 {
+		t.Fatalf("Position = %+v; want offset %d on line 1", p, len(in))
+	}
+}
+
+func TestNextRune(t *testing.T) {
+	in := "  ж\n € \xff"
+	r := NewReader(iotest.OneByteReader(strings.NewReader(in)), WithUnicodeSpaces())
+	for _, want := range []rune{'ж', '€', utf8.RuneError} {
+		c, err := r.NextRune()
+		if err != nil || c != want {
+			t.Fatalf("NextRune = %q, %v; want %q", c, err, want)
+		}
+	}
+	if _, err := r.NextRune(); !errors.Is(err, io.EOF) {
+		t.Fatalf("Expected EOF error, got: %v", err)
+	}
+}
+
+func TestInvalidUTF8Error(t *testing.T) {
+	r := NewReader(strings.NewReader("ok bad\xffword \xfe x"), WithInvalidUTF8(InvalidUTF8Error))
+	if w, err := r.NextWord(); err != nil || w != "ok" {
+		t.Fatalf("NextWord = %q, %v; want ok", w, err)
+	}
+	_, err := r.NextWord()
+	var pe *ParseError
+	if !errors.As(err, &pe) || !errors.Is(err, ErrInvalidUTF8) {
+		t.Fatalf("Expected ParseError with ErrInvalidUTF8, got: %v", err)
+	}
+	if pe.Func != "NextWord" || pe.Col != 4 || pe.Token != "bad\xffword" {
+		t.Fatalf("ParseError = %+v; want NextWord, token bad\\xffword at col 4", *pe)
+	}
+
+	c, size, err := r.ReadRune()
+	if c != ' ' || size != 1 || err != nil {
+		t.Fatalf("ReadRune = %q, %d, %v; want ' '", c, size, err)
+	}
+	c, _, err = r.ReadRune()
+	if c != utf8.RuneError || !errors.As(err, &pe) || !errors.Is(err, ErrInvalidUTF8) {
+		t.Fatalf("ReadRune = %q, %v; want RuneError with ErrInvalidUTF8", c, err)
+	}
+	if pe.Func != "ReadRune" || pe.Col != 13 {
+		t.Fatalf("ParseError = %+v; want ReadRune at col 13", *pe)
+	}
+	if c, err := r.NextRune(); err != nil || c != 'x' {
+		t.Fatalf("NextRune = %q, %v; want x", c, err)
+	}
+}
+
+func TestInvalidUTF8Modes(t *testing.T) {
+	const input = "ok bad\xffword\nline \xfe\xfdend\r\nок\n"
+	type result struct {
+		word, line string
+		err        bool
+	}
+	for _, mode := range []InvalidUTF8Mode{InvalidUTF8Replace, InvalidUTF8Error} {
+		for _, size := range []int{16, 4096} {
+			r := NewReader(iotest.OneByteReader(strings.NewReader(input)), WithBufferSize(size), WithInvalidUTF8(mode))
+			if w, err := r.NextWordBytes(); err != nil || string(w) != "ok" {
+				t.Fatalf("mode %d: NextWordBytes = %q, %v; want ok", mode, w, err)
+			}
+			w, werr := r.NextWord()
+			_, _ = r.NextLine() // остаток первой строки
+			l, lerr := r.NextLine()
+			ok, okErr := r.NextLineBytes()
+			if okErr != nil || string(ok) != "ок" {
+				t.Fatalf("mode %d: NextLineBytes = %q, %v; want ок", mode, ok, okErr)
+			}
+			var pe *ParseError
+			switch mode {
+			case InvalidUTF8Replace:
+				if werr != nil || w != "bad�word" {
+					t.Errorf("replace, buffer %d: NextWord = %q, %v", size, w, werr)
+				}
+				if lerr != nil || l != "line ��end" {
+					t.Errorf("replace, buffer %d: NextLine = %q, %v", size, l, lerr)
+				}
+			case InvalidUTF8Error:
+				if !errors.As(werr, &pe) || !errors.Is(werr, ErrInvalidUTF8) || pe.Func != "NextWord" || pe.Line != 1 || pe.Col != 4 {
+					t.Errorf("error, buffer %d: NextWord error = %v; want ErrInvalidUTF8 at 1:4", size, werr)
+				}
+				if !errors.As(lerr, &pe) || !errors.Is(lerr, ErrInvalidUTF8) || pe.Func != "NextLine" ||
+					pe.Line != 2 || pe.Col != 1 || pe.Offset != 12 || pe.Token != "line \xfe\xfdend" {
+					t.Errorf("error, buffer %d: NextLine error = %v; want ErrInvalidUTF8 at 2:1", size, lerr)
+				}
+			}
+		}
+	}
+}
+
+func TestInvalidUTF8ScanAndDecode(t *testing.T) {
+	var s string
+	var b []byte
+	r := NewReader(strings.NewReader("a\xff b\xff"))
+	if _, err := r.Scan(&s, &b); err != nil || s != "a�" || string(b) != "b�" {
+		t.Fatalf("Scan = %q, %q, %v; want replaced bytes", s, b, err)
+	}
+	r = NewReader(strings.NewReader("a\xff"), WithInvalidUTF8(InvalidUTF8Error))
+	if _, err := r.Scan(&s); !errors.Is(err, ErrInvalidUTF8) {
+		t.Fatalf("Scan error = %v; want ErrInvalidUTF8", err)
+	}
+	// NextGrid читает байты как есть.
+	g, err := NewReader(strings.NewReader("\xff#"), WithInvalidUTF8(InvalidUTF8Error)).NextGrid(1, 2)
+	if err != nil || string(g[0]) != "\xff#" {
+		t.Fatalf("NextGrid = %q, %v; want raw bytes", g, err)
+	}
+}
@@ -0,0 +1,254 @@
+package fastio
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"reflect"
+)
+
+// ErrUnsupportedType возвращается Scan для аргумента, тип которого
+// нельзя заполнить из токена.
+var ErrUnsupportedType = errors.New("unsupported type")
+
+// Scan читает очередные токены в args, как fmt.Fscan: каждый аргумент —
+// указатель, в который записывается один токен, переводы строк считаются
+// обычными разделителями. Возвращает число успешно заполненных аргументов.
+//
+// Без рефлексии поддерживаются *int, *int8, *int16, *int32, *int64,
+// *uint, *uint8, *uint16, *uint32, *uint64, *uintptr, *float32, *float64,
+// *string, *[]byte, *bool и *big.Int. Указатели на именованные типы
+// с такими базовыми типами (type ID int) заполняются через reflect.
+// Целые, как и в fmt.Fscan, могут иметь префикс основания 0b, 0o
+// или 0x, ведущий 0 означает восьмеричное число, а между цифрами
+// допускается '_' (см. NextIntBase с основанием 0).
+// Строка и []byte получают копию токена; bool принимает значения
+// strconv.ParseBool ("1", "t", "true", "0", "f", "false" и т. п.).
+//
+// В отличие от fmt.Fscan, каждый аргумент получает весь токен до
+// разделителя: для "12abc" fmt.Fscan запишет 12 и оставит "abc" во входе,
+// а Scan вернёт ErrSyntax, считав токен целиком. Значения bool в другом
+// регистре ("tRuE") и с хвостом ("trueish") тоже дают ErrSyntax.
+//
+// Ошибки разбора возвращаются как *ParseError с Func = "Scan".
+// Если вход закончился до первого аргумента, возвращается io.EOF,
+// если после — io.ErrUnexpectedEOF. Неподдерживаемый тип даёт
+// ошибку ErrUnsupportedType.
+func (fr *FastReader) Scan(args ...any) (int, error) {
+	for i, arg := range args {
+		if err := fr.scanOne(arg); err != nil {
+			if i > 0 && err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return i, err
+		}
+	}
+	return len(args), nil
+}
+
+func (fr *FastReader) scanOne(arg any) error {
+	var err error
+	switch p := arg.(type) {
+	case *int:
+		*p, err = scanNumber[int](fr)
+	case *int8:
+		*p, err = scanNumber[int8](fr)
+	case *int16:
+		*p, err = scanNumber[int16](fr)
+	case *int32:
+		*p, err = scanNumber[int32](fr)
+	case *int64:
+		*p, err = scanNumber[int64](fr)
+	case *uint:
+		*p, err = scanNumber[uint](fr)
+	case *uint8:
+		*p, err = scanNumber[uint8](fr)
+	case *uint16:
+		*p, err = scanNumber[uint16](fr)
+	case *uint32:
+		*p, err = scanNumber[uint32](fr)
+	case *uint64:
+		*p, err = scanNumber[uint64](fr)
+	case *uintptr:
+		*p, err = scanNumber[uintptr](fr)
+	case *float64:
+		*p, err = scanNumber[float64](fr)
+	case *float32:
+		*p, err = scanNumber[float32](fr)
+	case *string:
+		var b []byte
+		if b, err = fr.word("Scan"); err == nil {
+			*p = string(b)
+		}
+	case *[]byte:
+		var b []byte
+		if b, err = fr.word("Scan"); err == nil {
+			*p = append((*p)[:0], b...)
+		}
+	case *bool:
+		*p, err = fr.scanBool("Scan")
+	case *big.Int:
+		err = fr.NextBigInt(p)
+		if pe, ok := err.(*ParseError); ok {
+			pe.Func = "Scan"
+		}
+	default:
+		err = fr.scanReflect(arg)
+	}
+	return err
+}
+
+// scanNumber читает число типа T для Scan. Целые читаются
+// по правилам fmt.Fscan, см. scanInteger.
+func scanNumber[T Number](fr *FastReader) (T, error) {
+	k := kindOf[T]()
+	if k.float {
+		return next[T](fr, "Scan", k)
+	}
+	val, neg, err := fr.scanInteger("Scan", k.signed, k.max)
+	if err != nil {
+		return 0, err
+	}
+	if neg {
+		return T(-int64(val)), nil
+	}
+	return T(val), nil
+}
+
+// scanInteger читает целое по правилам fmt.Fscan: как nextIntegerBase
+// с основанием 0. Обычные десятичные числа, целиком лежащие в буфере,
+// читаются быстрым nextInteger.
+func (fr *FastReader) scanInteger(fn string, signed bool, max uint64) (uint64, bool, error) {
+	if err := fr.SkipSpaces(); err != nil {
+		return 0, false, err
+	}
+	if fr.plainDecimal() {
+		return fr.nextInteger(fn, signed, max)
+	}
+	return fr.nextIntegerBase(fn, 0, signed, max)
+}
+
+// plainDecimal сообщает, что следующий токен целиком лежит в буфере
+// и состоит из необязательного знака и цифр без ведущего нуля.
+func (fr *FastReader) plainDecimal() bool {
+	i := fr.pos
+	if i < fr.n && (fr.buf[i] == '+' || fr.buf[i] == '-') {
+		i++
+	}
+	if i >= fr.n || fr.buf[i] < '1' || fr.buf[i] > '9' {
+		return false
+	}
+	for ; i < fr.n; i++ {
+		if b := fr.buf[i]; b < '0' || b > '9' {
+			return fr.delims[b]
+		}
+	}
+	return fr.err != nil
+}
+
+func (fr *FastReader) scanFloat(fn string, bitSize int) (float64, error) {
+	if err := fr.SkipSpaces(); err != nil {
+		return 0, err
+	}
+	start := fr.offset()
+	token, err := fr.nextWordBytes(fn)
+	if err != nil {
+		return 0, err
+	}
+	var v float64
+	if bitSize == 32 {
+		var f float32
+		f, err = atof32(token)
+		v = float64(f)
+	} else {
+		v, err = atof64(token)
+	}
+	if err != nil {
+		return 0, fr.parseError(fn, start, string(token), err)
+	}
+	return v, nil
+}
+
+func (fr *FastReader) scanBool(fn string) (bool, error) {
+	if err := fr.SkipSpaces(); err != nil {
+		return false, err
+	}
+	start := fr.offset()
+	token, err := fr.nextWordBytes(fn)
+	if err != nil {
+		return false, err
+	}
+	switch string(token) {
+	case "1", "t", "T", "true", "TRUE", "True":
+		return true, nil
+	case "0", "f", "F", "false", "FALSE", "False":
+		return false, nil
+	}
+	return false, fr.parseError(fn, start, string(token), ErrSyntax)
+}
+
+// scanReflect заполняет указатели на именованные типы с базовыми
+// числовыми, строковыми и логическими типами.
+func (fr *FastReader) scanReflect(arg any) error {
+	v := reflect.ValueOf(arg)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return fmt.Errorf("fastio: Scan: %w %T", ErrUnsupportedType, arg)
+	}
+	return fr.scanValue("Scan", v.Elem())
+}
+
+// scanValue читает один токен в v по его базовому типу.
+func (fr *FastReader) scanValue(fn string, v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		max := uint64(1)<<(v.Type().Bits()-1) - 1
+		val, neg, err := fr.scanInteger(fn, true, max)
+		if err != nil {
+			return err
+		}
+		if neg {
+			v.SetInt(-int64(val))
+		} else {
+			v.SetInt(int64(val))
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		max := uint64(math.MaxUint64) >> (64 - v.Type().Bits())
+		val, _, err := fr.scanInteger(fn, false, max)
+		if err != nil {
+			return err
+		}
+		v.SetUint(val)
+	case reflect.Float32, reflect.Float64:
+		f, err := fr.scanFloat(fn, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.String:
+		b, err := fr.word(fn)
+		if err != nil {
+			return err
+		}
+		v.SetString(string(b))
+	case reflect.Bool:
+		b, err := fr.scanBool(fn)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.Uint8 {
+			return fmt.Errorf("fastio: %s: %w %s", fn, ErrUnsupportedType, v.Type())
+		}
+		b, err := fr.word(fn)
+		if err != nil {
+			return err
+		}
+		v.SetBytes(append(v.Bytes()[:0], b...))
+	default:
+		return fmt.Errorf("fastio: %s: %w %s", fn, ErrUnsupportedType, v.Type())
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package fastio
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestScan(t *testing.T) {
+	type ID int32
+	type Name string
+
+	r := newTestReader("-5 300 -128 70000 x\n7 255 42 1e3 2.5 word bytes true 0 123456789012345678901234 17 ann")
+	var (
+		a   int
+		b   int16
+		c   int8
+		d   int64
+		s   string
+		u   uint
+		u8  uint8
+		u64 uint64
+		f64 float64
+		f32 float32
+		w   string
+		bs  []byte
+		t1  bool
+		t2  bool
+		bi  big.Int
+		id  ID
+		nm  Name
+	)
+	n, err := r.Scan(&a, &b, &c, &d, &s, &u, &u8, &u64, &f64, &f32, &w, &bs, &t1, &t2, &bi, &id, &nm)
+	if err != nil || n != 17 {
+		t.Fatalf("Scan = %d, %v; want 17, nil", n, err)
+	}
+	if a != -5 || b != 300 || c != -128 || d != 70000 || s != "x" {
+		t.Fatalf("got %d %d %d %d %q", a, b, c, d, s)
+	}
+	if u != 7 || u8 != 255 || u64 != 42 || f64 != 1000 || f32 != 2.5 {
+		t.Fatalf("got %d %d %d %v %v", u, u8, u64, f64, f32)
+	}
+	if w != "word" || string(bs) != "bytes" || !t1 || t2 {
+		t.Fatalf("got %q %q %v %v", w, bs, t1, t2)
+	}
+	if bi.String() != "123456789012345678901234" || id != 17 || nm != "ann" {
+		t.Fatalf("got %s %d %q", bi.String(), id, nm)
+	}
+
+	if n, err := r.Scan(&a); n != 0 || !errors.Is(err, io.EOF) {
+		t.Fatalf("Scan at EOF = %d, %v; want 0, EOF", n, err)
+	}
+}
+
+func TestScanIntegerPrefixesMatchFscan(t *testing.T) {
+	type ID uint16
+	for _, in := range []string{"0x1F", "-0X1f", "0o17", "0b101", "017", "0", "+0", "1_000", "0x_ff", "-42"} {
+		var want, got int64
+		if _, err := fmt.Fscan(strings.NewReader(in), &want); err != nil {
+			t.Fatalf("fmt.Fscan(%q) error: %v", in, err)
+		}
+		r := newTestReader(in + " next")
+		if _, err := r.Scan(&got); err != nil || got != want {
+			t.Errorf("Scan(%q) = %d, %v; want %d", in, got, err, want)
+		}
+		if w, _ := r.NextWord(); w != "next" {
+			t.Errorf("after Scan(%q) next word = %q; want %q", in, w, "next")
+		}
+	}
+	var id ID
+	if _, err := newTestReader("0x10").Scan(&id); err != nil || id != 16 {
+		t.Errorf("Scan(0x10) into named type = %d, %v; want 16", id, err)
+	}
+}
+
+func TestScanErrors(t *testing.T) {
+	var (
+		a int
+		c int8
+		u uint8
+		x bool
+	)
+	n, err := newTestReader("1 128").Scan(&a, &c)
+	var pe *ParseError
+	if n != 1 || !errors.As(err, &pe) || !errors.Is(err, ErrRange) || pe.Func != "Scan" || pe.Token != "128" {
+		t.Fatalf("Scan int8 overflow = %d, %v; want 1, ErrRange", n, err)
+	}
+	if _, err := newTestReader("256").Scan(&u); !errors.Is(err, ErrRange) {
+		t.Fatalf("Scan uint8 overflow: expected ErrRange, got: %v", err)
+	}
+	if _, err := newTestReader("-1").Scan(&u); !errors.Is(err, ErrNoDigits) {
+		t.Fatalf("Scan negative uint8: expected ErrNoDigits, got: %v", err)
+	}
+	if _, err := newTestReader("yes").Scan(&x); !errors.Is(err, ErrSyntax) {
+		t.Fatalf("Scan bool: expected ErrSyntax, got: %v", err)
+	}
+	if n, err := newTestReader("1 ").Scan(&a, &a); n != 1 || err != io.ErrUnexpectedEOF {
+		t.Fatalf("Scan short input = %d, %v; want 1, io.ErrUnexpectedEOF", n, err)
+	}
+	var m map[string]int
+	if _, err := newTestReader("1").Scan(&m); !errors.Is(err, ErrUnsupportedType) {
+		t.Fatalf("Scan map: expected ErrUnsupportedType, got: %v", err)
+	}
+	if _, err := newTestReader("1").Scan(a); !errors.Is(err, ErrUnsupportedType) {
+		t.Fatalf("Scan non-pointer: expected ErrUnsupportedType, got: %v", err)
+	}
+}
+
+func TestScanWholeTokens(t *testing.T) {
+	// Scan строже fmt.Fscan: аргумент получает весь токен до разделителя
+	// или ошибку, а bool принимает только значения strconv.ParseBool.
+	// Ошибочный токен считывается целиком.
+	var (
+		i int
+		f float64
+		b bool
+	)
+	cases := []struct {
+		in  string
+		arg any
+	}{
+		{"12abc", &i},
+		{"0x1fg", &i},
+		{"1.5x", &f},
+		{"tRuE", &b},
+		{"trueish", &b},
+	}
+	for _, tc := range cases {
+		if n, err := fmt.Fscan(strings.NewReader(tc.in), tc.arg); n != 1 || err != nil {
+			t.Fatalf("fmt.Fscan(%q) = %d, %v; want 1, nil", tc.in, n, err)
+		}
+		r := newTestReader(tc.in + " next")
+		n, err := r.Scan(tc.arg)
+		var pe *ParseError
+		if n != 0 || !errors.As(err, &pe) || !errors.Is(err, ErrSyntax) || pe.Token != tc.in || pe.Offset != 0 {
+			t.Errorf("Scan(%q) = %d, %v; want 0 and ParseError with ErrSyntax for the whole token", tc.in, n, err)
+		}
+		if w, _ := r.NextWord(); w != "next" {
+			t.Errorf("after Scan(%q) next word = %q; want %q", tc.in, w, "next")
+		}
+	}
+}
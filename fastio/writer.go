@@ -15,12 +15,25 @@
 package fastio
 
 import (
+	"context"
+	"errors"
 	"io"
+	"math/big"
 	"strconv"
+	"time"
 )
 
 const defaultWriterBufSize = 64 * 1024 // 64KB
 
+// ErrInvalidToken возвращается WriteToken и WriteTokenBytes для пустого
+// токена или токена, содержащего разделитель.
+var ErrInvalidToken = errors.New("empty token or token with delimiter")
+
+// ErrLenMismatch возвращается функциями записи, которые генерирует
+// fastiogen, если длина среза с тегом len=Field не равна значению
+// поля Field: такую запись нельзя прочитать обратно.
+var ErrLenMismatch = errors.New("slice length does not match its len field")
+
 type FastWriter struct {
 	w   io.Writer
 	buf []byte
@@ -31,6 +44,13 @@ type FastWriter struct {
 	limit     int
 
 	scratch []byte
+	// words — рабочая копия слов для WriteBigInt.
+	words []big.Word
+
+	// ctx отменяет Flush (см. WithContext), deadline —
+	// SetWriteDeadline writer'а w, если он его поддерживает.
+	ctx      context.Context
+	deadline func(time.Time) error
 }
 
 type writerError struct {
@@ -53,34 +73,35 @@ func (we writerError) Is(target error) bool {
 }
 
 // NewWriter создаёт FastWriter поверх io.Writer.
-// Буфер создаётся размером 64 KB.
+// По умолчанию буфер создаётся размером 64 KB; размер можно
+// изменить опцией WithBufferSize, автосброс включается WithAutoFlush.
 //
 // Важно: не забывайте вызывать Flush() перед завершением работы.
-func NewWriter(w io.Writer) *FastWriter {
+func NewWriter(w io.Writer, opts ...Option) *FastWriter {
+	o := newOptions(defaultWriterBufSize, opts)
+	limit := o.flushLimit
+	if limit <= 0 || limit > o.bufSize {
+		limit = o.bufSize / 2
+	}
 	return &FastWriter{
 		w:         w,
-		buf:       make([]byte, defaultWriterBufSize),
-		autoFlush: false,
-		limit:     defaultWriterBufSize / 2,
+		buf:       make([]byte, o.bufSize),
+		autoFlush: o.autoFlush,
+		limit:     limit,
 		scratch:   make([]byte, 0, 64),
+		ctx:       o.ctx,
+		deadline:  writeDeadline(o.ctx, w),
 	}
 }
 
 // NewWriterWithAutoFlush включает автоматический сброс буфера,
 // когда размер данных достигает limit байт.
-// limit рекомендуется устанавливать ≤ размера внутреннего буфера.
-func NewWriterWithAutoFlush(w io.Writer, limit int) *FastWriter {
-	if limit <= 0 || limit > defaultWriterBufSize {
-		limit = defaultWriterBufSize / 2
-	}
-	return &FastWriter{
-		w:         w,
-		buf:       make([]byte, defaultWriterBufSize),
-		autoFlush: true,
-		limit:     limit,
-		scratch:   make([]byte, 0, 64),
-	}
-
+// limit рекомендуется устанавливать ≤ размера внутреннего буфера;
+// иначе используется половина размера буфера.
+//
+// Эквивалентно NewWriter(w, WithAutoFlush(limit), opts...).
+func NewWriterWithAutoFlush(w io.Writer, limit int, opts ...Option) *FastWriter {
+	return NewWriter(w, append([]Option{WithAutoFlush(limit)}, opts...)...)
 }
 
 // Err возвращает первую возникшую ошибку записи.
@@ -89,8 +110,24 @@ func (fw *FastWriter) Err() error {
 	return fw.err
 }
 
+// Reset переключает FastWriter на запись в w, отбрасывая
+// несброшенные данные и ошибку. Буфер, настройки автосброса
+// и контекст WithContext сохраняются.
+func (fw *FastWriter) Reset(w io.Writer) {
+	fw.w = w
+	fw.deadline = writeDeadline(fw.ctx, w)
+	fw.pos = 0
+	fw.err = nil
+}
+
+// Buffered возвращает число байт в буфере, ещё не переданных в io.Writer.
+func (fw *FastWriter) Buffered() int {
+	return fw.pos
+}
+
 // Flush сбрасывает внутренний буфер в базовый io.Writer.
 // Если базовый writer возвращает ошибку — она хранится в Err().
+// С WithContext отмена контекста прерывает сброс с ошибкой ctx.Err().
 func (fw *FastWriter) Flush() error {
 	if fw.err != nil {
 		return fw.err
@@ -98,7 +135,7 @@ func (fw *FastWriter) Flush() error {
 	if fw.pos == 0 {
 		return nil
 	}
-	n, err := fw.w.Write(fw.buf[:fw.pos])
+	n, err := fw.write(fw.buf[:fw.pos])
 	if err != nil {
 		fw.err = writerError{err: err}
 		return err
@@ -124,7 +161,7 @@ func (fw *FastWriter) ensureSpace(n int) error {
 		if err := fw.Flush(); err != nil {
 			return err
 		}
-		_, err := fw.w.Write(fw.buf[:0])
+		_, err := fw.write(fw.buf[:0])
 		if err != nil {
 			fw.err = writerError{err: err}
 		}
@@ -142,7 +179,7 @@ func (fw *FastWriter) checkWriter() error {
 	if fw.err != nil {
 		return fw.err
 	}
-	if _, err := fw.w.Write(nil); err != nil {
+	if _, err := fw.write(nil); err != nil {
 		fw.err = writerError{err: err}
 		return fw.err
 	}
@@ -152,6 +189,12 @@ func (fw *FastWriter) checkWriter() error {
 // Write реализует интерфейс io.Writer.
 // Записывает данные в буфер с последующим Flush при необходимости.
 func (fw *FastWriter) Write(p []byte) (int, error) {
+	return writeData(fw, p)
+}
+
+// writeData — общая реализация Write и WriteString:
+// copy работает и со строками, поэтому строка не конвертируется в []byte.
+func writeData[T ~[]byte | ~string](fw *FastWriter, p T) (int, error) {
 	if fw.err != nil {
 		return 0, fw.err
 	}
@@ -193,8 +236,36 @@ func (fw *FastWriter) WriteBytes(b []byte) error {
 }
 
 // WriteString записывает строку без дополнительных символов.
+// Строка копируется в буфер напрямую, без преобразования в []byte.
 func (fw *FastWriter) WriteString(s string) error {
-	return fw.WriteBytes([]byte(s))
+	_, err := writeData(fw, s)
+	return err
+}
+
+// WriteToken записывает s как один токен, который FastReader с
+// разделителями по умолчанию прочитает обратно через NextWord.
+// Пустая строка или строка с байтом из SpaceDelimiters не пишется,
+// а возвращается ErrInvalidToken; ошибка не сохраняется в Err().
+func (fw *FastWriter) WriteToken(s string) error {
+	return writeToken(fw, s)
+}
+
+// WriteTokenBytes работает как WriteToken для []byte.
+func (fw *FastWriter) WriteTokenBytes(b []byte) error {
+	return writeToken(fw, b)
+}
+
+func writeToken[T ~[]byte | ~string](fw *FastWriter, tok T) error {
+	if len(tok) == 0 {
+		return ErrInvalidToken
+	}
+	for i := 0; i < len(tok); i++ {
+		if defaultDelimiters[tok[i]] {
+			return ErrInvalidToken
+		}
+	}
+	_, err := writeData(fw, tok)
+	return err
 }
 
 // WriteLine записывает строку и добавляет символ '\n'.
@@ -229,3 +300,11 @@ func (fw *FastWriter) WriteInt64(v int64) error {
 	fw.scratch = strconv.AppendInt(fw.scratch[:0], v, 10)
 	return fw.WriteBytes(fw.scratch)
 }
+
+// WriteBool записывает "true" или "false".
+func (fw *FastWriter) WriteBool(v bool) error {
+	if v {
+		return fw.WriteString("true")
+	}
+	return fw.WriteString("false")
+}
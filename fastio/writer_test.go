@@ -3,6 +3,7 @@ package fastio
 import (
 	"bytes"
 	"errors"
+	"io"
 	"testing"
 )
 
@@ -169,3 +170,89 @@ func TestWriteInt64(t *testing.T) {
 		t.Fatalf("unexpected output.\nwant: %q\ngot:  %q", want, got)
 	}
 }
+
+func TestWriteBool(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	_ = w.WriteBool(true)
+	_ = w.WriteByte(' ')
+	_ = w.WriteBool(false)
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush error: %v", err)
+	}
+	if got := buf.String(); got != "true false" {
+		t.Fatalf("unexpected output: %q", got)
+	}
+}
+
+func TestWriteToken(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	for _, bad := range []string{"", "a b", "tab\t", "line\n"} {
+		if err := w.WriteToken(bad); !errors.Is(err, ErrInvalidToken) {
+			t.Fatalf("WriteToken(%q) = %v; want ErrInvalidToken", bad, err)
+		}
+		if err := w.WriteTokenBytes([]byte(bad)); !errors.Is(err, ErrInvalidToken) {
+			t.Fatalf("WriteTokenBytes(%q) = %v; want ErrInvalidToken", bad, err)
+		}
+	}
+	_ = w.WriteToken("ok")
+	_ = w.WriteTokenBytes([]byte("-x-"))
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush error: %v", err)
+	}
+	if got := buf.String(); got != "ok-x-" {
+		t.Fatalf("unexpected output: %q", got)
+	}
+}
+
+func TestWriterWithSmallBuffer(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, WithBufferSize(16))
+
+	if err := w.WriteLine("a line that is longer than the buffer"); err != nil {
+		t.Fatalf("WriteLine failed: %v", err)
+	}
+	if err := w.WriteInt64(-9223372036854775808); err != nil {
+		t.Fatalf("WriteInt64 failed: %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	got := buf.String()
+	want := "a line that is longer than the buffer\n-9223372036854775808"
+	if got != want {
+		t.Errorf("Output mismatch: got %q, want %q", got, want)
+	}
+}
+
+func TestAutoFlushLimitUsesBufferSize(t *testing.T) {
+	w := NewWriterWithAutoFlush(io.Discard, 1<<20, WithBufferSize(4<<20))
+	if w.limit != 1<<20 {
+		t.Fatalf("limit = %d; want %d", w.limit, 1<<20)
+	}
+
+	w = NewWriter(io.Discard, WithBufferSize(1024), WithAutoFlush(4096))
+	if !w.autoFlush || w.limit != 512 {
+		t.Fatalf("autoFlush = %v, limit = %d; want true, 512", w.autoFlush, w.limit)
+	}
+}
+
+func TestAutoFlushWritesAtLimit(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, WithBufferSize(64), WithAutoFlush(8))
+
+	if err := w.WriteString("1234567"); err != nil {
+		t.Fatalf("WriteString failed: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("Expected no flush before limit, got %q", buf.String())
+	}
+	if err := w.WriteByte('8'); err != nil {
+		t.Fatalf("WriteByte failed: %v", err)
+	}
+	if buf.String() != "12345678" {
+		t.Fatalf("Expected flush at limit, got %q", buf.String())
+	}
+}